@@ -7,9 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"mime/multipart"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/thehxdev/telbot/types"
@@ -20,8 +21,20 @@ type Bot struct {
 	BaseUrl     string
 	BaseFileUrl string
 	Self        *types.User
+	// Optional rate limiter for outgoing requests. Nil disables throttling.
+	Limiter *RateLimiter
+	// Maximum size of files downloaded with OpenFile and DownloadFile.
+	// Defaults to 20MB, the limit of servers hosted by telegram. Raise it
+	// when using a local Bot API server. Zero means no limit.
+	MaxDownloadSize int64
+	// Called with errors that happen while polling updates. Defaults to
+	// logging them with the standard logger.
+	OnError func(err error)
+
 	client      http.Client
-	updatesChan chan Update
+	pollingMu   sync.Mutex
+	stopPolling context.CancelFunc
+	pollingDone chan struct{}
 }
 
 type UpdateHandler func(update Update) error
@@ -30,13 +43,19 @@ type RequestInfo struct {
 	Body        io.Reader
 	Method      string
 	ContentType string
+	// Timeout of a single attempt. Time spent waiting for the rate limiter
+	// is not included. Zero means no timeout.
+	Timeout time.Duration
+	// Target chat of the request, used by the rate limiter
+	ChatId int
 }
 
 type APIResponse struct {
-	Ok          bool            `json:"ok"`
-	Result      json.RawMessage `json:"result"`
-	ErrorCode   int             `json:"error_code,omitempty"`
-	Description string          `json:"description,omitempty"`
+	Ok          bool                      `json:"ok"`
+	Result      json.RawMessage           `json:"result"`
+	ErrorCode   int                       `json:"error_code,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	Parameters  *types.ResponseParameters `json:"parameters,omitempty"`
 }
 
 func createMethodUrl(baseUrl string, method string) string {
@@ -51,10 +70,11 @@ func New(token string, host ...string) (*Bot, error) {
 	}
 
 	b := &Bot{
-		Token:       token,
-		BaseUrl:     fmt.Sprintf("https://%s/bot%s", h, token),
-		BaseFileUrl: fmt.Sprintf("https://%s/file/bot%s", h, token),
-		client:      http.Client{},
+		Token:           token,
+		BaseUrl:         fmt.Sprintf("https://%s/bot%s", h, token),
+		BaseFileUrl:     fmt.Sprintf("https://%s/file/bot%s", h, token),
+		client:          http.Client{},
+		MaxDownloadSize: defaultMaxDownloadSize,
 	}
 
 	botUser, err := b.GetMe(context.Background())
@@ -67,11 +87,26 @@ func New(token string, host ...string) (*Bot, error) {
 }
 
 func (b *Bot) SendRequest(ctx context.Context, baseUrl string, info RequestInfo) (APIResponse, error) {
+	if b.Limiter == nil {
+		return b.sendRequest(ctx, baseUrl, info)
+	}
+	return b.Limiter.do(ctx, info, func() (APIResponse, error) {
+		return b.sendRequest(ctx, baseUrl, info)
+	})
+}
+
+func (b *Bot) sendRequest(ctx context.Context, baseUrl string, info RequestInfo) (APIResponse, error) {
 	var (
 		httpResp *http.Response
 		resp     APIResponse
 	)
 
+	if info.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, info.Timeout)
+		defer cancel()
+	}
+
 	reqUrl := createMethodUrl(baseUrl, info.Method)
 	req, err := http.NewRequestWithContext(ctx, "POST", reqUrl, info.Body)
 	if err != nil {
@@ -91,7 +126,7 @@ func (b *Bot) SendRequest(ctx context.Context, baseUrl string, info RequestInfo)
 		goto ret
 	}
 	if !resp.Ok {
-		err = errors.New(resp.Description)
+		err = newAPIError(resp)
 	}
 
 ret:
@@ -101,14 +136,13 @@ ret:
 func (b *Bot) GetUpdates(ctx context.Context, params UpdateParams) ([]Update, error) {
 	updates := []Update{}
 
-	reqCtx, cancel := context.WithTimeout(ctx, time.Second*time.Duration(params.Timeout))
-	defer cancel()
-
 	body, _ := ParamsToReader(params)
-	apiResp, err := b.SendRequest(reqCtx, b.BaseUrl, RequestInfo{
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
 		Method:      MethodGetUpdates,
 		Body:        body,
 		ContentType: ContentTypeApplicationJson,
+		// leave the server time to answer an idle long poll
+		Timeout: time.Second*time.Duration(params.Timeout) + longPollingTimeoutMargin,
 	})
 	if err != nil {
 		return nil, err
@@ -121,50 +155,51 @@ func (b *Bot) GetUpdates(ctx context.Context, params UpdateParams) ([]Update, er
 	return updates, nil
 }
 
-func (b *Bot) StartPolling(ctx context.Context, params UpdateParams) (<-chan Update, error) {
-	b.updatesChan = make(chan Update, params.Limit)
-
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-			updates, err := b.GetUpdates(ctx, params)
-			if err != nil {
-				log.Println(err)
-				time.Sleep(time.Second * 5)
-				continue
-			}
-			for _, update := range updates {
-				if update.Id >= params.Offset {
-					params.Offset = update.Id + 1
-					update.Bot = b
-					b.updatesChan <- update
-				}
-			}
-			time.Sleep(getUpdatesSleepTime)
+// UploadFile sends the files with a multipart/form-data request to the
+// method returned by params.UploadMethod. The typed methods such as
+// SendPhoto and SendDocument are built on top of this.
+func (b *Bot) UploadFile(ctx context.Context, params IUploadParams, files []IFileInfo) (*types.Message, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no files provided to upload")
+	}
+	for _, file := range files {
+		if isNilFile(file) {
+			return nil, errors.New("nil file provided to upload")
 		}
-	}()
+	}
 
-	return b.updatesChan, nil
-}
+	pMap, err := params.ToStringMap()
+	if err != nil {
+		return nil, err
+	}
 
-func (b *Bot) UploadFile(ctx context.Context, params UploadParams, files []IFileInfo) (*types.Message, error) {
-	if len(files) == 0 {
-		return nil, errors.New("no files provided to upload")
+	apiResp, err := b.sendMultipart(ctx, params.UploadMethod(), pMap, files)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &types.Message{}
+	if err := json.NewDecoder(bytes.NewReader(apiResp.Result)).Decode(msg); err != nil {
+		return nil, err
 	}
 
+	return msg, nil
+}
+
+// sendMultipart streams fields and files to the method without buffering
+// the files in memory. Files that don't have a reader (file ids and URLs)
+// are sent as plain fields.
+func (b *Bot) sendMultipart(ctx context.Context, method string, fields map[string]string, files []IFileInfo) (APIResponse, error) {
 	pipeReader, pipeWriter := io.Pipe()
+	// unblocks the writer goroutine if the request fails early
+	defer pipeReader.Close()
 	multipartWriter := multipart.NewWriter(pipeWriter)
 
 	go func() {
 		defer pipeWriter.Close()
 		defer multipartWriter.Close()
 
-		pMap, _ := params.ToStringMap()
-		for key, value := range pMap {
+		for key, value := range fields {
 			if err := multipartWriter.WriteField(key, value); err != nil {
 				pipeWriter.CloseWithError(err)
 				return
@@ -177,6 +212,13 @@ func (b *Bot) UploadFile(ctx context.Context, params UploadParams, files []IFile
 				pipeWriter.CloseWithError(err)
 				return
 			}
+			if fileReader == nil {
+				if err := multipartWriter.WriteField(file.FileKind(), fileName); err != nil {
+					pipeWriter.CloseWithError(err)
+					return
+				}
+				continue
+			}
 			part, err := multipartWriter.CreateFormFile(file.FileKind(), fileName)
 			if err != nil {
 				pipeWriter.CloseWithError(err)
@@ -196,73 +238,55 @@ func (b *Bot) UploadFile(ctx context.Context, params UploadParams, files []IFile
 		}
 	}()
 
-	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
-		Method:      "sendDocument",
+	chatId, _ := strconv.Atoi(fields["chat_id"])
+	return b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      method,
 		Body:        pipeReader,
 		ContentType: multipartWriter.FormDataContentType(),
+		ChatId:      chatId,
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	msg := &types.Message{}
-	if err := json.NewDecoder(bytes.NewReader(apiResp.Result)).Decode(msg); err != nil {
-		return nil, err
-	}
-
-	return msg, nil
 }
 
 func (b *Bot) GetMe(ctx context.Context) (*types.User, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
-	defer cancel()
-
 	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
 		Method:      MethodGetMe,
 		Body:        nil,
 		ContentType: "",
+		Timeout:     defaultOperationTimeout,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	if !apiResp.Ok {
-		return nil, errors.New(apiResp.Description)
-	}
-
 	u := &types.User{}
 	err = json.Unmarshal(apiResp.Result, u)
 	return u, err
 }
 
 func (b *Bot) LogOut(ctx context.Context) (bool, error) {
-	reqCtx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
-	defer cancel()
-	apiResp, err := b.SendRequest(reqCtx, b.BaseUrl, RequestInfo{
-		Method: "logOut",
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:  "logOut",
+		Timeout: defaultOperationTimeout,
 	})
 	return apiResp.Ok, err
 }
 
 // This method is the implementation of the "close" method of telegram bot api
 func (b *Bot) Close(ctx context.Context) (bool, error) {
-	reqCtx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
-	defer cancel()
-	apiResp, err := b.SendRequest(reqCtx, b.BaseUrl, RequestInfo{
-		Method: "close",
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:  "close",
+		Timeout: defaultOperationTimeout,
 	})
 	return apiResp.Ok, err
 }
 
 func (b *Bot) GetFile(ctx context.Context, fileId string) (*types.File, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
-	defer cancel()
-
 	body, _ := ParamsToReader(map[string]string{"file_id": fileId})
 	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
 		Method:      MethodGetFile,
 		Body:        body,
 		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
 	})
 	if err != nil {
 		return nil, err
@@ -278,14 +302,13 @@ func (b *Bot) GetFile(ctx context.Context, fileId string) (*types.File, error) {
 }
 
 func (b *Bot) SendMessage(ctx context.Context, params TextMessageParams) (*types.Message, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
-	defer cancel()
-
 	body, _ := ParamsToReader(params)
 	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
 		Method:      MethodSendMessage,
 		Body:        body,
 		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+		ChatId:      params.ChatId,
 	})
 	if err != nil {
 		return nil, err
@@ -301,14 +324,13 @@ func (b *Bot) SendMessage(ctx context.Context, params TextMessageParams) (*types
 }
 
 func (b *Bot) EditMessageText(ctx context.Context, params EditMessageTextParams) (*types.Message, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
-	defer cancel()
-
 	body, _ := ParamsToReader(params)
 	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
 		Method:      MethodEditMessageText,
 		Body:        body,
 		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+		ChatId:      params.ChatId,
 	})
 	if err != nil {
 		return nil, err
@@ -324,14 +346,58 @@ func (b *Bot) EditMessageText(ctx context.Context, params EditMessageTextParams)
 }
 
 func (b *Bot) DeleteMessage(ctx context.Context, chatId, messageId int) error {
-	ctx, cancel := context.WithTimeout(ctx, defaultOperationTimeout)
-	defer cancel()
-
 	body, _ := ParamsToReader(map[string]int{"chat_id": chatId, "message_id": messageId})
 	_, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
 		Method:      MethodDeleteMessage,
 		Body:        body,
 		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
 	})
 	return err
 }
+
+func (b *Bot) SetMessageReaction(ctx context.Context, params SetMessageReactionParams) (bool, error) {
+	if params.Reaction == nil {
+		params.Reaction = []types.IReactionType{}
+	}
+	body, _ := ParamsToReader(params)
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodSetMessageReaction,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+// AnswerCallbackQuery must be called for every callback query, otherwise
+// the client keeps showing a progress bar on the pressed button.
+func (b *Bot) AnswerCallbackQuery(ctx context.Context, params AnswerCallbackQueryParams) (bool, error) {
+	body, _ := ParamsToReader(params)
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodAnswerCallbackQuery,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+// AnswerInlineQuery sends at most 50 results for an inline query. Use
+// InlineQuery.Page to paginate larger result sets.
+func (b *Bot) AnswerInlineQuery(ctx context.Context, params AnswerInlineQueryParams) (bool, error) {
+	if params.Results == nil {
+		params.Results = []types.IInlineQueryResult{}
+	}
+	body, err := ParamsToReader(params)
+	if err != nil {
+		return false, err
+	}
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodAnswerInlineQuery,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
@@ -0,0 +1,61 @@
+package telbot
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testToken = "123:test"
+
+// newTestBot returns a bot that sends its requests to a test server.
+// methods maps Bot API method names to their handlers.
+func newTestBot(t *testing.T, methods map[string]http.HandlerFunc) (*Bot, *httptest.Server) {
+	t.Helper()
+	mux := http.NewServeMux()
+	for method, handler := range methods {
+		mux.HandleFunc("/bot"+testToken+"/"+method, handler)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return &Bot{
+		Token:           testToken,
+		BaseUrl:         srv.URL + "/bot" + testToken,
+		BaseFileUrl:     srv.URL + "/file/bot" + testToken,
+		MaxDownloadSize: defaultMaxDownloadSize,
+	}, srv
+}
+
+// writeResult writes a successful Bot API response with result
+func writeResult(w http.ResponseWriter, result any) {
+	b, _ := json.Marshal(result)
+	json.NewEncoder(w).Encode(APIResponse{Ok: true, Result: b})
+}
+
+// writeError writes a failed Bot API response
+func writeError(w http.ResponseWriter, resp APIResponse) {
+	w.WriteHeader(resp.ErrorCode)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func TestAnswerInlineQueryWithoutResults(t *testing.T) {
+	var body map[string]json.RawMessage
+	bot, _ := newTestBot(t, map[string]http.HandlerFunc{
+		MethodAnswerInlineQuery: func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &body); err != nil {
+				t.Error(err)
+			}
+			writeResult(w, true)
+		},
+	})
+	if _, err := bot.AnswerInlineQuery(context.Background(), AnswerInlineQueryParams{InlineQueryId: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(body["results"]); got != "[]" {
+		t.Errorf("results = %s, want []", got)
+	}
+}
@@ -6,6 +6,10 @@ const (
 	defaultInvalidId        = -1
 	defaultOperationTimeout = time.Second * 5
 	getUpdatesSleepTime     = time.Second * 1
+	pollingMinBackoff       = time.Millisecond * 500
+	pollingMaxBackoff       = time.Second * 30
+	// Added to the long polling timeout of getUpdates requests
+	longPollingTimeoutMargin = time.Second * 5
 )
 
 const (
@@ -15,6 +19,15 @@ const (
 	ChatTypeChannel    string = "channel"
 )
 
+// Maximum length of a text message in UTF-16 code units
+const MaxMessageLength = 4096
+
+const (
+	ParseModeHTML       = "HTML"
+	ParseModeMarkdownV2 = "MarkdownV2"
+	ParseModeMarkdown   = "Markdown"
+)
+
 const (
 	ContentTypeFormUrlEncoded    = "application/x-www-form-urlencoded"
 	ContentTypeMultipartFormData = "multipart/form-data"
@@ -22,29 +35,58 @@ const (
 )
 
 const (
-	MethodGetMe           = "getMe"
-	MethodGetUpdates      = "getUpdates"
-	MethodSendMessage     = "sendMessage"
-	MethodGetFile         = "getFile"
-	MethodEditMessageText = "editMessageText"
-	MethodDeleteMessage   = "deleteMessage"
+	MethodGetMe               = "getMe"
+	MethodGetUpdates          = "getUpdates"
+	MethodSendMessage         = "sendMessage"
+	MethodGetFile             = "getFile"
+	MethodEditMessageText     = "editMessageText"
+	MethodDeleteMessage       = "deleteMessage"
+	MethodSetMessageReaction  = "setMessageReaction"
+	MethodSendPhoto           = "sendPhoto"
+	MethodSendAudio           = "sendAudio"
+	MethodSendDocument        = "sendDocument"
+	MethodSendVideo           = "sendVideo"
+	MethodSendAnimation       = "sendAnimation"
+	MethodSendVoice           = "sendVoice"
+	MethodSendVideoNote       = "sendVideoNote"
+	MethodSendMediaGroup      = "sendMediaGroup"
+	MethodAnswerCallbackQuery = "answerCallbackQuery"
+	MethodAnswerInlineQuery   = "answerInlineQuery"
+	MethodSetWebhook          = "setWebhook"
+	MethodDeleteWebhook       = "deleteWebhook"
+	MethodGetWebhookInfo      = "getWebhookInfo"
+
+	MethodBanChatMember                   = "banChatMember"
+	MethodUnbanChatMember                 = "unbanChatMember"
+	MethodRestrictChatMember              = "restrictChatMember"
+	MethodPromoteChatMember               = "promoteChatMember"
+	MethodSetChatAdministratorCustomTitle = "setChatAdministratorCustomTitle"
+	MethodBanChatSenderChat               = "banChatSenderChat"
+	MethodUnbanChatSenderChat             = "unbanChatSenderChat"
+	MethodSetChatPermissions              = "setChatPermissions"
+	MethodGetChatMember                   = "getChatMember"
+	MethodGetChatAdministrators           = "getChatAdministrators"
+	MethodGetChatMemberCount              = "getChatMemberCount"
 )
 
 const (
-	MessageEntityTypeMention       = "mention"
-	MessageEntityTypeHashtag       = "hashtag"
-	MessageEntityTypeCashtag       = "cachtag"
-	MessageEntityTypeBotCommand    = "bot_command"
-	MessageEntityTypeUrl           = "url"
-	MessageEntityTypeEmail         = "email"
-	MessageEntityTypePhoneNumber   = "phone_number"
-	MessageEntityTypeBold          = "bold"
-	MessageEntityTypeItalic        = "italic"
-	MessageEntityTypeUnderline     = "underline"
-	MessageEntityTypeStrikeThrough = "strikethrough"
-	MessageEntityTypeSpoiler       = "spoiler"
-	MessageEntityTypeCode          = "code"
-	MessageEntityTypePre           = "pre"
-	MessageEntityTypeTextLink      = "text_link"
-	MessageEntityTypeTextMention   = "text_mention"
+	MessageEntityTypeMention              = "mention"
+	MessageEntityTypeHashtag              = "hashtag"
+	MessageEntityTypeCashtag              = "cashtag"
+	MessageEntityTypeBotCommand           = "bot_command"
+	MessageEntityTypeUrl                  = "url"
+	MessageEntityTypeEmail                = "email"
+	MessageEntityTypePhoneNumber          = "phone_number"
+	MessageEntityTypeBold                 = "bold"
+	MessageEntityTypeItalic               = "italic"
+	MessageEntityTypeUnderline            = "underline"
+	MessageEntityTypeStrikeThrough        = "strikethrough"
+	MessageEntityTypeSpoiler              = "spoiler"
+	MessageEntityTypeCode                 = "code"
+	MessageEntityTypePre                  = "pre"
+	MessageEntityTypeTextLink             = "text_link"
+	MessageEntityTypeTextMention          = "text_mention"
+	MessageEntityTypeBlockquote           = "blockquote"
+	MessageEntityTypeExpandableBlockquote = "expandable_blockquote"
+	MessageEntityTypeCustomEmoji          = "custom_emoji"
 )
@@ -0,0 +1,105 @@
+package telbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Bot API servers hosted by telegram don't serve files larger than 20MB
+const defaultMaxDownloadSize = 20 << 20
+
+var ErrFileTooLarge = errors.New("file is larger than the download limit")
+
+// OpenFile resolves the file path with GetFile and opens the file for
+// reading. When the bot is connected to a local Bot API server, the
+// file path is an absolute path and the file is opened from disk.
+// Reading fails if the file is larger than Bot.MaxDownloadSize or its
+// size doesn't match the size reported by telegram.
+func (b *Bot) OpenFile(ctx context.Context, fileId string) (io.ReadCloser, error) {
+	file, err := b.GetFile(ctx, fileId)
+	if err != nil {
+		return nil, err
+	}
+	if file.FilePath == "" {
+		return nil, fmt.Errorf("file %s is not available for download", fileId)
+	}
+	if b.MaxDownloadSize > 0 && int64(file.FileSize) > b.MaxDownloadSize {
+		return nil, ErrFileTooLarge
+	}
+
+	var rc io.ReadCloser
+	if filepath.IsAbs(file.FilePath) {
+		rc, err = os.Open(file.FilePath)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		rc, err = b.fetchFile(ctx, file.FilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &checkedReader{
+		rc:       rc,
+		max:      b.MaxDownloadSize,
+		expected: int64(file.FileSize),
+	}, nil
+}
+
+// DownloadFile writes the content of the file to w and returns the
+// number of bytes written.
+func (b *Bot) DownloadFile(ctx context.Context, fileId string, w io.Writer) (int64, error) {
+	rc, err := b.OpenFile(ctx, fileId)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	return io.Copy(w, rc)
+}
+
+func (b *Bot) fetchFile(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, createMethodUrl(b.BaseFileUrl, filePath), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("downloading file: %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// checkedReader enforces the size limit and verifies the file size once
+// the whole file is read.
+type checkedReader struct {
+	rc       io.ReadCloser
+	read     int64
+	max      int64
+	expected int64
+}
+
+func (cr *checkedReader) Read(p []byte) (int, error) {
+	n, err := cr.rc.Read(p)
+	cr.read += int64(n)
+	if cr.max > 0 && cr.read > cr.max {
+		return n, ErrFileTooLarge
+	}
+	if err == io.EOF && cr.expected > 0 && cr.read != cr.expected {
+		return n, fmt.Errorf("file size mismatch: expected %d bytes, got %d", cr.expected, cr.read)
+	}
+	return n, err
+}
+
+func (cr *checkedReader) Close() error {
+	return cr.rc.Close()
+}
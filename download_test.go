@@ -0,0 +1,98 @@
+package telbot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+func TestDownloadFile(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "local.txt")
+	if err := os.WriteFile(localPath, []byte("from disk"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// files known to the test getFile method, by file id
+	files := map[string]types.File{
+		"ok":             {FilePath: "documents/ok.txt", FileSize: 5},
+		"unknown size":   {FilePath: "documents/ok.txt"},
+		"reported large": {FilePath: "documents/large.txt", FileSize: 11},
+		"actually large": {FilePath: "documents/large.txt"},
+		"mismatch":       {FilePath: "documents/ok.txt", FileSize: 4},
+		"missing":        {FilePath: "documents/missing.txt", FileSize: 5},
+		"no path":        {},
+		"local":          {FilePath: localPath, FileSize: 9},
+	}
+	bot, _ := newTestBot(t, map[string]http.HandlerFunc{
+		MethodGetFile: func(w http.ResponseWriter, r *http.Request) {
+			params := map[string]string{}
+			json.NewDecoder(r.Body).Decode(&params)
+			file := files[params["file_id"]]
+			file.FileId = params["file_id"]
+			writeResult(w, file)
+		},
+	})
+	fileServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/file/bot"+testToken+"/") {
+		case "documents/ok.txt":
+			w.Write([]byte("hello"))
+		case "documents/large.txt":
+			w.Write([]byte("hello world"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer fileServer.Close()
+	bot.BaseFileUrl = fileServer.URL + "/file/bot" + testToken
+	bot.MaxDownloadSize = 10
+
+	tests := []struct {
+		fileId  string
+		want    string
+		wantErr string
+	}{
+		{"ok", "hello", ""},
+		{"unknown size", "hello", ""},
+		{"local", "from disk", ""},
+		{"reported large", "", ErrFileTooLarge.Error()},
+		{"actually large", "", ErrFileTooLarge.Error()},
+		{"mismatch", "", "file size mismatch"},
+		{"missing", "", "404"},
+		{"no path", "", "not available"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileId, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			n, err := bot.DownloadFile(context.Background(), tt.fileId, &buf)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DownloadFile = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want || n != int64(len(tt.want)) {
+				t.Errorf("downloaded %d bytes %q, want %q", n, buf.String(), tt.want)
+			}
+		})
+	}
+
+	if _, err := bot.DownloadFile(context.Background(), "actually large", &bytes.Buffer{}); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("DownloadFile = %v, want ErrFileTooLarge", err)
+	}
+	bot.MaxDownloadSize = 0
+	if _, err := bot.DownloadFile(context.Background(), "reported large", &bytes.Buffer{}); err != nil {
+		t.Errorf("DownloadFile without a limit = %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package telbot
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+// APIError is returned by every method when telegram responds with
+// `ok: false`. Use errors.As to inspect it.
+type APIError struct {
+	Code        int
+	Description string
+	Parameters  *types.ResponseParameters
+}
+
+func newAPIError(resp APIResponse) *APIError {
+	return &APIError{
+		Code:        resp.ErrorCode,
+		Description: resp.Description,
+		Parameters:  resp.Parameters,
+	}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram: %s (%d)", e.Description, e.Code)
+}
+
+// RetryAfter returns the time to wait before repeating the request if
+// the error was caused by flood control.
+func (e *APIError) RetryAfter() time.Duration {
+	if e.Parameters == nil {
+		return 0
+	}
+	return time.Duration(e.Parameters.RetryAfter) * time.Second
+}
+
+// MigrateToChatId returns the new id of a group that has been migrated
+// to a supergroup, or zero.
+func (e *APIError) MigrateToChatId() int {
+	if e.Parameters == nil {
+		return 0
+	}
+	return e.Parameters.MigrateToChatId
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// IsForbidden reports whether the bot has no rights to do the action
+// (e.g. the bot was blocked by the user or kicked from the chat).
+func IsForbidden(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.Code == http.StatusForbidden
+}
+
+// IsFloodWait reports whether the request was rejected by flood control.
+func IsFloodWait(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && (apiErr.Code == http.StatusTooManyRequests || apiErr.RetryAfter() > 0)
+}
+
+// IsChatMigrated reports whether the target group was migrated to a
+// supergroup. Use APIError.MigrateToChatId to get the new chat id.
+func IsChatMigrated(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.MigrateToChatId() != 0
+}
+
+// IsMessageNotModified reports whether an edit request was rejected
+// because the new content is the same as the current one.
+func IsMessageNotModified(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.Code == http.StatusBadRequest &&
+		strings.Contains(apiErr.Description, "message is not modified")
+}
@@ -0,0 +1,83 @@
+package telbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+func TestErrorPredicates(t *testing.T) {
+	forbidden := &APIError{Code: 403, Description: "Forbidden: bot was blocked by the user"}
+	flood := &APIError{Code: 429, Description: "Too Many Requests: retry after 5", Parameters: &types.ResponseParameters{RetryAfter: 5}}
+	migrated := &APIError{Code: 400, Description: "Bad Request: group chat was upgraded to a supergroup chat", Parameters: &types.ResponseParameters{MigrateToChatId: -100123}}
+	notModified := &APIError{Code: 400, Description: "Bad Request: message is not modified: specified new message content and reply markup are exactly the same"}
+	badRequest := &APIError{Code: 400, Description: "Bad Request: chat not found"}
+
+	tests := []struct {
+		name                                    string
+		err                                     error
+		forbidden, flood, migrated, notModified bool
+	}{
+		{"forbidden", forbidden, true, false, false, false},
+		{"flood wait", flood, false, true, false, false},
+		{"429 without retry_after", &APIError{Code: 429}, false, true, false, false},
+		{"chat migrated", migrated, false, false, true, false},
+		{"message not modified", notModified, false, false, false, true},
+		{"other bad request", badRequest, false, false, false, false},
+		{"wrapped", fmt.Errorf("sending: %w", forbidden), true, false, false, false},
+		{"not an API error", errors.New("Forbidden"), false, false, false, false},
+		{"nil", nil, false, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsForbidden(tt.err); got != tt.forbidden {
+				t.Errorf("IsForbidden = %v", got)
+			}
+			if got := IsFloodWait(tt.err); got != tt.flood {
+				t.Errorf("IsFloodWait = %v", got)
+			}
+			if got := IsChatMigrated(tt.err); got != tt.migrated {
+				t.Errorf("IsChatMigrated = %v", got)
+			}
+			if got := IsMessageNotModified(tt.err); got != tt.notModified {
+				t.Errorf("IsMessageNotModified = %v", got)
+			}
+		})
+	}
+
+	if flood.RetryAfter() != 5*time.Second || migrated.MigrateToChatId() != -100123 {
+		t.Errorf("RetryAfter() = %v, MigrateToChatId() = %d", flood.RetryAfter(), migrated.MigrateToChatId())
+	}
+	if forbidden.RetryAfter() != 0 || forbidden.MigrateToChatId() != 0 {
+		t.Error("errors without parameters must return zero values")
+	}
+}
+
+func TestSendRequestReturnsAPIError(t *testing.T) {
+	bot, _ := newTestBot(t, map[string]http.HandlerFunc{
+		MethodSendMessage: func(w http.ResponseWriter, r *http.Request) {
+			writeError(w, APIResponse{
+				ErrorCode:   http.StatusTooManyRequests,
+				Description: "Too Many Requests: retry after 3",
+				Parameters:  &types.ResponseParameters{RetryAfter: 3},
+			})
+		},
+	})
+
+	_, err := bot.SendMessage(context.Background(), TextMessageParams{ChatId: 1, Text: "hi"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("SendMessage = %v, want an *APIError", err)
+	}
+	if apiErr.Code != http.StatusTooManyRequests || apiErr.Description != "Too Many Requests: retry after 3" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+	if apiErr.RetryAfter() != 3*time.Second || !IsFloodWait(err) {
+		t.Errorf("RetryAfter() = %v, IsFloodWait = %v", apiErr.RetryAfter(), IsFloodWait(err))
+	}
+}
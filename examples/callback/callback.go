@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/thehxdev/telbot"
+	"github.com/thehxdev/telbot/ext/dispatcher"
+	"github.com/thehxdev/telbot/ext/keyboard"
+)
+
+const BOT_TOKEN = "your_awesome_bot_token"
+
+func main() {
+	bot, err := telbot.New(BOT_TOKEN)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	updatesChan, err := bot.StartPolling(ctx, telbot.UpdateParams{
+		Timeout: 30,
+		Limit:   100,
+		AllowedUpdates: []telbot.UpdateKind{
+			telbot.UpdateKindMessage,
+			telbot.UpdateKindCallbackQuery,
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	d := dispatcher.New(4)
+	d.Handle(startHandler, dispatcher.Command("start"))
+	d.Handle(colorHandler, dispatcher.CallbackPrefix("color:"))
+
+	log.Println("started polling updates")
+	d.Start(ctx, updatesChan)
+}
+
+func startHandler(update telbot.Update) error {
+	markup, err := keyboard.NewInline().
+		Row(keyboard.Callback("Red", "color:red"), keyboard.Callback("Blue", "color:blue")).
+		Build()
+	if err != nil {
+		return err
+	}
+	_, err = update.Bot.SendMessage(context.Background(), telbot.TextMessageParams{
+		ChatId:      update.ChatId(),
+		Text:        "Pick a color",
+		ReplyMarkup: markup,
+	})
+	return err
+}
+
+func colorHandler(update telbot.Update) error {
+	ctx := context.Background()
+	color := strings.TrimPrefix(update.CallbackQuery.Data, "color:")
+	if err := update.AnswerCallback(ctx, "You picked "+color); err != nil {
+		return err
+	}
+
+	// the message is inaccessible if it's too old or was deleted
+	msg := update.CallbackQuery.Message
+	if msg == nil || !msg.IsAccessible() {
+		return nil
+	}
+	_, err := update.Bot.EditMessageText(ctx, telbot.EditMessageTextParams{
+		ChatId:    update.ChatId(),
+		MessageId: msg.MessageId(),
+		Text:      "Your favorite color is " + color,
+	})
+	if telbot.IsMessageNotModified(err) {
+		return nil
+	}
+	return err
+}
@@ -3,8 +3,11 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
 
 	"github.com/thehxdev/telbot"
+	"github.com/thehxdev/telbot/ext/dispatcher"
 )
 
 const BOT_TOKEN = "your_awesome_bot_token"
@@ -16,39 +19,29 @@ func main() {
 		log.Fatal(err)
 	}
 
-	ctx := context.Background()
+	// Polling stops and the updates channel is closed on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 	updatesChan, err := bot.StartPolling(ctx, telbot.UpdateParams{
 		Offset:         0,
 		Timeout:        30,
 		Limit:          100,
-		AllowedUpdates: []string{"message"},
+		AllowedUpdates: []telbot.UpdateKind{telbot.UpdateKindMessage},
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	d := dispatcher.New(8)
+	// Only handle private chats
+	private := dispatcher.ChatType(telbot.ChatTypePrivate)
+	d.Handle(StartHandler, private, dispatcher.Command("start"))
+	d.Handle(EchoHandler, private, dispatcher.IsMessage)
+
 	log.Println("started polling updates")
-	for update := range updatesChan {
-		if update.Message == nil {
-			continue
-		}
-		// Only handle private chats
-		if update.Message.Chat.Type != telbot.ChatTypePrivate {
-			continue
-		}
-		go func(update telbot.Update) {
-			var err error
-			switch update.Message.Text {
-			case "/start":
-				err = StartHandler(update)
-			default:
-				err = EchoHandler(update)
-			}
-			if err != nil {
-				log.Println(err)
-			}
-		}(update)
-	}
+	d.Start(context.Background(), updatesChan)
+	bot.Wait()
+	log.Println("stopped")
 }
 
 func StartHandler(update telbot.Update) error {
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/thehxdev/telbot"
+	"github.com/thehxdev/telbot/types"
+)
+
+const BOT_TOKEN = "your_awesome_bot_token"
+
+var words = strings.Fields("alpha bravo charlie delta echo foxtrot golf hotel india juliett kilo lima")
+
+func main() {
+	bot, err := telbot.New(BOT_TOKEN)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	updatesChan, err := bot.StartPolling(ctx, telbot.UpdateParams{
+		Timeout:        30,
+		Limit:          100,
+		AllowedUpdates: []telbot.UpdateKind{telbot.UpdateKindInlineQuery},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("started polling updates")
+	for update := range updatesChan {
+		if update.InlineQuery == nil {
+			continue
+		}
+		go func() {
+			if err := answer(ctx, update); err != nil {
+				log.Println(err)
+			}
+		}()
+	}
+}
+
+func answer(ctx context.Context, update telbot.Update) error {
+	query := update.InlineQuery
+	matches := []string{}
+	for _, w := range words {
+		if strings.HasPrefix(w, strings.ToLower(query.Query)) {
+			matches = append(matches, w)
+		}
+	}
+
+	// send 5 results at a time, the client requests the next page
+	// with the returned offset once the user scrolls down
+	start, end, nextOffset := query.Page(len(matches), 5)
+	results := []types.IInlineQueryResult{}
+	for i, w := range matches[start:end] {
+		results = append(results, &types.InlineQueryResultArticle{
+			Id:    fmt.Sprint(start + i),
+			Title: w,
+			InputMessageContent: &types.InputTextMessageContent{
+				MessageText: w,
+			},
+		})
+	}
+
+	_, err := update.Bot.AnswerInlineQuery(ctx, telbot.AnswerInlineQueryParams{
+		InlineQueryId: query.Id,
+		Results:       results,
+		NextOffset:    nextOffset,
+		IsPersonal:    true,
+	})
+	return err
+}
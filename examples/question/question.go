@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
+	"time"
 
 	"github.com/thehxdev/telbot"
 	conv "github.com/thehxdev/telbot/ext/conversation"
@@ -17,11 +19,34 @@ func main() {
 		log.Fatal(err)
 	}
 
-	updatesChan, _ := bot.StartPolling(context.Background(), telbot.UpdateParams{
+	// Conversations are saved to a file, so users can continue where they
+	// left off after the bot restarts.
+	store, err := conv.NewFileConversationStore("conversations.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	conv.SetConversationStore(store)
+
+	// Handlers are registered by state name. A handler moves the
+	// conversation to another state with `c.Goto`, `c.Back`, `c.Stay` or
+	// `c.End`.
+	conv.Handle("start", startHandler)
+	conv.Handle("name", nameHandler)
+	conv.Handle("age", ageHandler)
+	conv.OnEnd(endHandler)
+
+	// Users who don't answer within 5 minutes are dropped from the
+	// conversation and notified by the sweeper.
+	conv.SetIdleTimeout(5 * time.Minute)
+	conv.OnTimeout(timeoutHandler)
+	ctx := context.Background()
+	conv.StartSweeper(ctx, bot, time.Minute)
+
+	updatesChan, _ := bot.StartPolling(ctx, telbot.UpdateParams{
 		Offset:         0,
 		Limit:          100,
 		Timeout:        30,
-		AllowedUpdates: []string{"message"},
+		AllowedUpdates: []telbot.UpdateKind{telbot.UpdateKindMessage},
 	})
 
 	log.Println("started polling updates...")
@@ -34,16 +59,16 @@ func main() {
 			switch update.Message.Text {
 			case "/start":
 				// Start a new conversation once a "/start" command received
-				conv.Start(startHandler, update)
+				err = conv.Start("start", update)
 			default:
-				// Otherwise, there is no more routes. So check the userId and
-				// chatId for a conversatoin. If they belong to a conversation,
-				// call next handler registered with that.
+				// Otherwise, there is no more routes. So check if the update
+				// belongs to a conversation (by default, the same user in the
+				// same chat). If it does, call the handler of its state.
 				//
 				// NOTE: Ordering of the handlers matter! `telbot` is a low
 				// level library that does not provide any routing. So routing
 				// the updates and conversatoins must be handled by the user.
-				if conv.HasConversation(update.ChatId(), update.UserId()) {
+				if conv.HasConversation(update) {
 					err = conv.CallNext(update)
 				}
 			}
@@ -60,15 +85,69 @@ func startHandler(c *conv.Conversation, update telbot.Update) error {
 		Text:   "Hey! This is a question bot. What is your name?",
 	}
 	_, err := update.Bot.SendMessage(context.Background(), params)
-	c.Next = nameHandler
-	return err
+	if err != nil {
+		return err
+	}
+	return c.Goto("name")
 }
 
 func nameHandler(c *conv.Conversation, update telbot.Update) error {
+	if err := conv.Set(c, "name", update.Message.Text); err != nil {
+		return err
+	}
 	params := telbot.TextMessageParams{
 		ChatId: update.Message.Chat.Id,
-		Text:   fmt.Sprintf("Nice to meet you %s!", update.Message.Text),
+		Text:   "How old are you? (send \"back\" to change your name)",
+	}
+	_, err := update.Bot.SendMessage(context.Background(), params)
+	if err != nil {
+		return err
 	}
-	update.Bot.SendMessage(context.Background(), params)
-	return &conv.EndConversation{}
+	return c.Goto("age")
+}
+
+func ageHandler(c *conv.Conversation, update telbot.Update) error {
+	ctx := context.Background()
+	params := telbot.TextMessageParams{ChatId: update.Message.Chat.Id}
+
+	if update.Message.Text == "back" {
+		params.Text = "What is your name?"
+		_, err := update.Bot.SendMessage(ctx, params)
+		if err != nil {
+			return err
+		}
+		return c.Back()
+	}
+
+	age, err := strconv.Atoi(update.Message.Text)
+	if err != nil || age <= 0 {
+		params.Text = "Please send your age as a number."
+		_, err := update.Bot.SendMessage(ctx, params)
+		if err != nil {
+			return err
+		}
+		return c.Stay()
+	}
+
+	name, _ := conv.Get[string](c, "name")
+	return c.End(fmt.Sprintf("Nice to meet you %s! You are %d years old.", name, age))
+}
+
+// endHandler receives the result passed to `c.End`
+func endHandler(c *conv.Conversation, update telbot.Update, result any) error {
+	params := telbot.TextMessageParams{
+		ChatId: c.ChatId,
+		Text:   result.(string),
+	}
+	_, err := update.Bot.SendMessage(context.Background(), params)
+	return err
+}
+
+func timeoutHandler(ctx context.Context, bot *telbot.Bot, c *conv.Conversation) error {
+	params := telbot.TextMessageParams{
+		ChatId: c.ChatId,
+		Text:   "You took too long to answer. Send /start to try again.",
+	}
+	_, err := bot.SendMessage(ctx, params)
+	return err
 }
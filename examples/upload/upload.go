@@ -35,18 +35,17 @@ func main() {
 	}
 	defer file.Close()
 
-	fileInfo := &telbot.FileReader{
-		Kind:     "document",
-		FileName: filepath.Base(*path),
-		Reader:   file,
-	}
-	params := telbot.UploadParams{
+	params := telbot.DocumentParams{
 		ChatId: *chatid,
-		Method: "sendDocument",
+		Document: &telbot.FileReader{
+			FileName: filepath.Base(*path),
+			Reader:   file,
+		},
+		Caption: filepath.Base(*path),
 	}
 
 	log.Println("uploading file", *path)
-	msg, err := bot.UploadFile(context.Background(), params, []telbot.IFileInfo{fileInfo})
+	msg, err := bot.SendDocument(context.Background(), params)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/thehxdev/telbot"
+)
+
+const (
+	BOT_TOKEN    = "your_awesome_bot_token"
+	WEBHOOK_URL  = "https://example.com/telegram"
+	SECRET_TOKEN = "your_webhook_secret"
+)
+
+func main() {
+	bot, err := telbot.New(BOT_TOKEN)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	_, err = bot.SetWebhook(ctx, telbot.WebhookParams{
+		Url:            WEBHOOK_URL,
+		SecretToken:    SECRET_TOKEN,
+		AllowedUpdates: []telbot.UpdateKind{telbot.UpdateKindMessage},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	handler, updatesChan := bot.WebhookHandler(SECRET_TOKEN, 100)
+	http.Handle("/telegram", handler)
+	go func() {
+		// TLS is terminated by the reverse proxy in front of this server
+		log.Fatal(http.ListenAndServe("127.0.0.1:8080", nil))
+	}()
+
+	log.Println("listening for updates")
+	for update := range updatesChan {
+		if update.Message == nil {
+			continue
+		}
+		_, err := update.Bot.SendMessage(ctx, telbot.TextMessageParams{
+			ChatId: update.Message.Chat.Id,
+			Text:   update.Message.Text,
+		})
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
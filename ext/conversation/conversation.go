@@ -1,66 +1,288 @@
 package conversation
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
 	"github.com/thehxdev/telbot"
 )
 
 type Conversation struct {
-	Next   ConversationHandler
-	ChatId int
-	UserId int
+	// Name of the state whose handler receives the next update. Handlers
+	// move the conversation forward by changing it.
+	State string `json:"state"`
+	// Store key of the conversation, see KeyStrategy
+	Key string `json:"key"`
+	// Ids of the update that started the conversation, -1 if it had no
+	// chat or user
+	ChatId   int `json:"chat_id"`
+	UserId   int `json:"user_id"`
+	ThreadId int `json:"thread_id,omitempty"`
+
+	// Idle timeout of the conversation. ExpiresAt is moved forward by
+	// Timeout every time a handler runs. With a zero Timeout, handlers may
+	// set ExpiresAt themselves, a zero ExpiresAt never expires.
+	Timeout   time.Duration `json:"timeout,omitempty"`
+	ExpiresAt time.Time     `json:"expires_at"`
+
+	// Previous states, most recent last. Maintained by Goto and Back.
+	History []string `json:"history,omitempty"`
+
+	// JSON encoded user data. Use Get and Set to access it.
+	Data map[string]json.RawMessage `json:"data,omitempty"`
 }
 
+// Expired reports whether the conversation has expired at the given time
+func (c *Conversation) Expired(now time.Time) bool {
+	return !c.ExpiresAt.IsZero() && !now.Before(c.ExpiresAt)
+}
+
+// clone returns a deep copy of the conversation
+func (c *Conversation) clone() *Conversation {
+	cp := *c
+	cp.History = slices.Clone(c.History)
+	if c.Data != nil {
+		cp.Data = make(map[string]json.RawMessage, len(c.Data))
+		for k, v := range c.Data {
+			cp.Data[k] = slices.Clone(v)
+		}
+	}
+	return &cp
+}
+
+// ConversationStore implementations must treat expired conversations as
+// missing in Get and hand them out exactly once from RemoveExpired. Get
+// must return a copy that is not shared with other callers.
 type ConversationStore interface {
-	Store(userId int, conv *Conversation) error
-	Get(userId int) (*Conversation, error)
-	Remove(userId int) error
+	Store(key string, conv *Conversation) error
+	Get(key string) (*Conversation, error)
+	Remove(key string) error
+	// RemoveExpired removes and returns conversations that have expired at
+	// the given time
+	RemoveExpired(now time.Time) ([]*Conversation, error)
 }
 
 type ConversationHandler func(*Conversation, telbot.Update) error
 
-type EndConversation struct{}
+// TimeoutHandler is called for conversations that were idle for longer than
+// their timeout. The conversation is already removed from the store.
+type TimeoutHandler func(context.Context, *telbot.Bot, *Conversation) error
+
+// EndHandler is called with the result passed to Conversation.End after
+// the conversation is removed from the store.
+type EndHandler func(*Conversation, telbot.Update, any) error
+
+type EndConversation struct {
+	Result any
+}
 
 func (e *EndConversation) Error() string {
 	return "end conversation"
 }
 
+// Returned by ConversationStore.Get if there is no conversation for a key
+var ErrConversationNotFound = errors.New("conversation not found")
+
+const defaultSweepInterval = time.Minute
+
 var convStore ConversationStore = NewDefaultConversationStore()
 
+var (
+	mu             sync.RWMutex
+	handlers       = map[string]ConversationHandler{}
+	timeoutHandler TimeoutHandler
+	endHandler     EndHandler
+	idleTimeout    time.Duration
+)
+
 func SetConversationStore(cs ConversationStore) {
 	convStore = cs
 }
 
-func HasConversation(chatId, userId int) bool {
-	if conv, err := convStore.Get(userId); err == nil {
-		return conv.ChatId == chatId
+// SetIdleTimeout sets the default timeout of new conversations. Handlers
+// can override it for a single conversation with Conversation.Timeout.
+func SetIdleTimeout(d time.Duration) {
+	mu.Lock()
+	idleTimeout = d
+	mu.Unlock()
+}
+
+// OnTimeout registers the handler called by the sweeper for expired
+// conversations, e.g. to tell the user the conversation was cancelled.
+func OnTimeout(handler TimeoutHandler) {
+	mu.Lock()
+	timeoutHandler = handler
+	mu.Unlock()
+}
+
+// OnEnd registers the handler called when a conversation ends
+func OnEnd(handler EndHandler) {
+	mu.Lock()
+	endHandler = handler
+	mu.Unlock()
+}
+
+// StartSweeper removes expired conversations every interval and passes them
+// to the OnTimeout handler. It stops when ctx is done. A non-positive
+// interval defaults to one minute.
+func StartSweeper(ctx context.Context, bot *telbot.Bot, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				sweep(ctx, bot, now)
+			}
+		}
+	}()
+}
+
+func sweep(ctx context.Context, bot *telbot.Bot, now time.Time) {
+	expired, err := convStore.RemoveExpired(now)
+	if err != nil {
+		log.Println(err)
+	}
+
+	mu.RLock()
+	handler := timeoutHandler
+	mu.RUnlock()
+	if handler == nil {
+		return
+	}
+	for _, conv := range expired {
+		if err := handler(ctx, bot, conv); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// Handle registers the handler for a state. Handlers are looked up by name
+// so conversations can be persisted and resumed after a restart.
+func Handle(state string, handler ConversationHandler) {
+	mu.Lock()
+	handlers[state] = handler
+	mu.Unlock()
+}
+
+func handlerFor(state string) (ConversationHandler, error) {
+	mu.RLock()
+	handler, ok := handlers[state]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no handler registered for state %q", state)
+	}
+	return handler, nil
+}
+
+// HasConversation reports whether the update belongs to an active
+// conversation
+func HasConversation(update telbot.Update) bool {
+	key, err := keyFor(update)
+	if err != nil {
+		return false
 	}
-	return false
+	_, err = convStore.Get(key)
+	return err == nil
 }
 
+// CallNext passes the update to the handler of its conversation's current
+// state. Any update with a user or chat (depending on KeyStrategy) works,
+// e.g. messages, edited messages and callback queries.
 func CallNext(update telbot.Update) error {
-	userId := update.Message.From.Id
+	key, err := keyFor(update)
+	if err != nil {
+		return err
+	}
 
-	conv, err := convStore.Get(userId)
+	conv, err := convStore.Get(key)
 	if err != nil {
 		return err
 	}
 
-	err = conv.Next(conv, update)
-	switch err.(type) {
-	case *EndConversation:
-		convStore.Remove(userId)
-		err = nil
+	// move the deadline forward before the handler runs, so the sweeper
+	// doesn't time out a conversation the user has just answered
+	if conv.Timeout > 0 {
+		conv.ExpiresAt = time.Now().Add(conv.Timeout)
+		if err := convStore.Store(key, conv); err != nil {
+			return err
+		}
 	}
 
-	return err
+	return run(conv, update)
 }
 
-func Start(startHandler ConversationHandler, update telbot.Update) {
-	userId := update.Message.From.Id
+// Start begins a new conversation in the given state and passes the update
+// to the state's handler
+func Start(state string, update telbot.Update) error {
+	key, err := keyFor(update)
+	if err != nil {
+		return err
+	}
+
+	mu.RLock()
+	timeout := idleTimeout
+	mu.RUnlock()
+
 	c := &Conversation{
-		UserId: userId,
-		ChatId: update.Message.Chat.Id,
+		Timeout:  timeout,
+		State:    state,
+		Key:      key,
+		UserId:   update.UserId(),
+		ChatId:   update.ChatId(),
+		ThreadId: threadId(update),
+		Data:     map[string]json.RawMessage{},
+	}
+	return run(c, update)
+}
+
+// run calls the handler of the conversation's current state and stores the
+// conversation afterwards, even if the handler failed
+func run(conv *Conversation, update telbot.Update) error {
+	handler, err := handlerFor(conv.State)
+	if err != nil {
+		return err
 	}
-	_ = convStore.Store(userId, c)
-	startHandler(c, update)
+
+	err = handler(conv, update)
+	// End may be wrapped by the handler
+	var end *EndConversation
+	if errors.As(err, &end) {
+		if err := convStore.Remove(conv.Key); err != nil {
+			return err
+		}
+		mu.RLock()
+		onEnd := endHandler
+		mu.RUnlock()
+		if onEnd != nil {
+			return onEnd(conv, update, end.Result)
+		}
+		return nil
+	}
+
+	// The conversation expired while the handler was running, so the
+	// sweeper may have already removed it and called OnTimeout. Storing it
+	// again would bring it back.
+	now := time.Now()
+	if conv.Expired(now) {
+		return err
+	}
+	if conv.Timeout > 0 {
+		conv.ExpiresAt = now.Add(conv.Timeout)
+	}
+	if storeErr := convStore.Store(conv.Key, conv); err == nil {
+		err = storeErr
+	}
+	return err
 }
@@ -0,0 +1,140 @@
+package conversation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileConversationStore keeps conversations in a JSON file so they survive
+// restarts. The whole file is rewritten atomically on every change.
+type FileConversationStore struct {
+	mu    sync.Mutex
+	path  string
+	table map[string]json.RawMessage
+}
+
+// NewFileConversationStore loads conversations from path. The file is
+// created on the first change if it doesn't exist.
+func NewFileConversationStore(path string) (*FileConversationStore, error) {
+	fs := &FileConversationStore{
+		path:  path,
+		table: make(map[string]json.RawMessage),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &fs.table); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", path, err)
+		}
+	}
+	return fs, nil
+}
+
+func (fs *FileConversationStore) Store(key string, conv *Conversation) error {
+	data, err := json.Marshal(conv)
+	if err != nil {
+		return err
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.table[key] = data
+	return fs.save()
+}
+
+// Get returns a copy of the stored conversation. Changes must be saved
+// with Store.
+func (fs *FileConversationStore) Get(key string) (*Conversation, error) {
+	fs.mu.Lock()
+	data, ok := fs.table[key]
+	fs.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w for key %q", ErrConversationNotFound, key)
+	}
+
+	conv := &Conversation{}
+	if err := json.Unmarshal(data, conv); err != nil {
+		return nil, err
+	}
+	if conv.Expired(time.Now()) {
+		return nil, fmt.Errorf("%w for key %q", ErrConversationNotFound, key)
+	}
+	return conv, nil
+}
+
+func (fs *FileConversationStore) Remove(key string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if _, ok := fs.table[key]; !ok {
+		return nil
+	}
+	delete(fs.table, key)
+	return fs.save()
+}
+
+// RemoveExpired removes and returns the conversations that expired
+// before now. Nothing is removed if a stored conversation can't be
+// decoded.
+func (fs *FileConversationStore) RemoveExpired(now time.Time) ([]*Conversation, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	expired := map[string]*Conversation{}
+	for key, data := range fs.table {
+		conv := &Conversation{}
+		if err := json.Unmarshal(data, conv); err != nil {
+			return nil, fmt.Errorf("decoding conversation %q: %w", key, err)
+		}
+		if conv.Expired(now) {
+			expired[key] = conv
+		}
+	}
+
+	removed := make([]*Conversation, 0, len(expired))
+	for key, conv := range expired {
+		delete(fs.table, key)
+		removed = append(removed, conv)
+	}
+	if len(removed) == 0 {
+		return removed, nil
+	}
+	return removed, fs.save()
+}
+
+// save writes the table to a temporary file and renames it over the old
+// one, so a crash never leaves a partially written file behind
+func (fs *FileConversationStore) save() error {
+	data, err := json.Marshal(fs.table)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fs.path), filepath.Base(fs.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), fs.path)
+}
@@ -3,40 +3,56 @@ package conversation
 import (
 	"fmt"
 	"sync"
+	"time"
 )
 
+// InMemoryConversationStore stores copies of conversations, so handlers
+// running concurrently never share one.
 type InMemoryConversationStore struct {
 	mu    sync.RWMutex
-	table map[int]*Conversation
+	table map[string]*Conversation
 }
 
 func NewDefaultConversationStore() *InMemoryConversationStore {
 	return &InMemoryConversationStore{
 		mu:    sync.RWMutex{},
-		table: make(map[int]*Conversation),
+		table: make(map[string]*Conversation),
 	}
 }
 
-func (cm *InMemoryConversationStore) Store(userId int, conv *Conversation) error {
+func (cm *InMemoryConversationStore) Store(key string, conv *Conversation) error {
 	cm.mu.Lock()
-	cm.table[userId] = conv
+	cm.table[key] = conv.clone()
 	cm.mu.Unlock()
 	return nil
 }
 
-func (cm *InMemoryConversationStore) Get(userId int) (*Conversation, error) {
+func (cm *InMemoryConversationStore) Get(key string) (*Conversation, error) {
 	cm.mu.RLock()
-	conv, ok := cm.table[userId]
+	conv, ok := cm.table[key]
 	cm.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("no conversation found for userId %d", userId)
+	if !ok || conv.Expired(time.Now()) {
+		return nil, fmt.Errorf("%w for key %q", ErrConversationNotFound, key)
 	}
-	return conv, nil
+	return conv.clone(), nil
 }
 
-func (cm *InMemoryConversationStore) Remove(userId int) error {
+func (cm *InMemoryConversationStore) Remove(key string) error {
 	cm.mu.Lock()
-	delete(cm.table, userId)
+	delete(cm.table, key)
 	cm.mu.Unlock()
 	return nil
 }
+
+func (cm *InMemoryConversationStore) RemoveExpired(now time.Time) ([]*Conversation, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	expired := []*Conversation{}
+	for key, conv := range cm.table {
+		if conv.Expired(now) {
+			expired = append(expired, conv)
+			delete(cm.table, key)
+		}
+	}
+	return expired, nil
+}
@@ -0,0 +1,155 @@
+package conversation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/thehxdev/telbot"
+	"github.com/thehxdev/telbot/types"
+)
+
+// reset replaces the global store and handlers for the duration of a test
+func reset(t *testing.T) {
+	t.Helper()
+	restore := func() {
+		mu.Lock()
+		handlers = map[string]ConversationHandler{}
+		timeoutHandler, endHandler, idleTimeout = nil, nil, 0
+		keyStrategy = KeyByUserInChat
+		mu.Unlock()
+		SetConversationStore(NewDefaultConversationStore())
+	}
+	restore()
+	t.Cleanup(restore)
+}
+
+func message(chatId, userId int, text string) telbot.Update {
+	return telbot.Update{Message: &types.Message{
+		Text: text,
+		Chat: &types.Chat{Id: chatId, Type: "group"},
+		From: &types.User{Id: userId},
+	}}
+}
+
+func TestConversationFlow(t *testing.T) {
+	reset(t)
+	Handle("name", func(c *Conversation, u telbot.Update) error {
+		Set(c, "name", u.Message.Text)
+		return c.Goto("age")
+	})
+	Handle("age", func(c *Conversation, u telbot.Update) error {
+		if u.Message.Text == "back" {
+			return c.Back()
+		}
+		age, err := strconv.Atoi(u.Message.Text)
+		if err != nil {
+			return c.Stay()
+		}
+		Set(c, "age", age)
+		return c.Goto("confirm")
+	})
+	Handle("confirm", func(c *Conversation, u telbot.Update) error {
+		// End is recognised even when it's wrapped
+		return fmt.Errorf("confirmed: %w", c.End(GetOr(c, "name", "")))
+	})
+
+	var ended *Conversation
+	var result any
+	OnEnd(func(c *Conversation, u telbot.Update, r any) error {
+		ended, result = c, r
+		return nil
+	})
+
+	steps := []struct {
+		text    string
+		state   string
+		history []string
+	}{
+		{"Ali", "age", []string{"name"}},
+		{"back", "name", []string{}},
+		{"Reza", "age", []string{"name"}},
+		{"not a number", "age", []string{"name"}},
+		{"30", "confirm", []string{"name", "age"}},
+	}
+	if err := Start("name", message(1, 2, steps[0].text)); err != nil {
+		t.Fatal(err)
+	}
+	for i, step := range steps {
+		if i > 0 {
+			if err := CallNext(message(1, 2, step.text)); err != nil {
+				t.Fatalf("%q: %v", step.text, err)
+			}
+		}
+		conv, err := convStore.Get("member:1:2")
+		if err != nil {
+			t.Fatalf("%q: %v", step.text, err)
+		}
+		if conv.State != step.state || !slices.Equal(conv.History, step.history) {
+			t.Errorf("after %q: state %q, history %v, want %q, %v", step.text, conv.State, conv.History, step.state, step.history)
+		}
+	}
+
+	if HasConversation(message(1, 3, "x")) {
+		t.Error("another user of the chat shares the conversation")
+	}
+	if err := CallNext(message(1, 2, "yes")); err != nil {
+		t.Fatal(err)
+	}
+	if HasConversation(message(1, 2, "x")) {
+		t.Error("conversation is still stored after End")
+	}
+	if ended == nil || result != "Reza" {
+		t.Fatalf("OnEnd called with %+v, %v", ended, result)
+	}
+	if age, _ := Get[int](ended, "age"); age != 30 {
+		t.Errorf("ended conversation has age %d, want 30", age)
+	}
+}
+
+func TestEndResult(t *testing.T) {
+	reset(t)
+	Handle("start", func(c *Conversation, u telbot.Update) error {
+		return c.End(nil)
+	})
+	// without OnEnd, ending is not an error
+	if err := Start("start", message(1, 2, "x")); err != nil {
+		t.Fatal(err)
+	}
+
+	errEnd := errors.New("end failed")
+	OnEnd(func(c *Conversation, u telbot.Update, r any) error {
+		return errEnd
+	})
+	if err := Start("start", message(1, 2, "x")); !errors.Is(err, errEnd) {
+		t.Errorf("Start = %v, want the OnEnd error", err)
+	}
+	if HasConversation(message(1, 2, "x")) {
+		t.Error("conversation is still stored after End")
+	}
+}
+
+func TestHandlerErrorKeepsConversation(t *testing.T) {
+	reset(t)
+	errHandler := errors.New("handler failed")
+	Handle("start", func(c *Conversation, u telbot.Update) error {
+		c.Goto("next")
+		return errHandler
+	})
+	if err := Start("start", message(1, 2, "x")); !errors.Is(err, errHandler) {
+		t.Fatalf("Start = %v, want the handler error", err)
+	}
+	conv, err := convStore.Get("member:1:2")
+	if err != nil || conv.State != "next" {
+		t.Errorf("Get = %+v, %v, want state \"next\"", conv, err)
+	}
+
+	if err := CallNext(message(1, 2, "x")); err == nil {
+		t.Error("CallNext succeeded without a handler for the state")
+	}
+	if err := CallNext(message(5, 2, "x")); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("CallNext without a conversation = %v, want ErrConversationNotFound", err)
+	}
+}
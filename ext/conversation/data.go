@@ -0,0 +1,44 @@
+package conversation
+
+import "encoding/json"
+
+// Get decodes the value stored under key into T. It reports false if there
+// is no such value or it can't be decoded into T.
+func Get[T any](c *Conversation, key string) (T, bool) {
+	var value T
+	data, ok := c.Data[key]
+	if !ok {
+		return value, false
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, false
+	}
+	return value, true
+}
+
+// GetOr is like Get but returns def if the value is missing
+func GetOr[T any](c *Conversation, key string, def T) T {
+	if value, ok := Get[T](c, key); ok {
+		return value
+	}
+	return def
+}
+
+// Set stores value under key. Values are kept JSON encoded, so they
+// decode the same way whether the conversation was persisted or not.
+func Set[T any](c *Conversation, key string, value T) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if c.Data == nil {
+		c.Data = map[string]json.RawMessage{}
+	}
+	c.Data[key] = data
+	return nil
+}
+
+// Delete removes the value stored under key
+func (c *Conversation) Delete(key string) {
+	delete(c.Data, key)
+}
@@ -0,0 +1,74 @@
+package conversation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thehxdev/telbot"
+)
+
+// KeyStrategy decides which updates belong to the same conversation
+type KeyStrategy int
+
+const (
+	// One conversation per user in each chat (default)
+	KeyByUserInChat KeyStrategy = iota
+	// One conversation per user across all chats
+	KeyByUser
+	// One conversation per chat shared by all of its members
+	KeyByChat
+	// One conversation per forum topic shared by all of its members.
+	// Messages outside of topics share the conversation of their chat.
+	KeyByTopic
+)
+
+// Returned if an update lacks the user or chat required by the key
+// strategy, e.g. an inline query with KeyByChat
+var ErrNoConversationKey = errors.New("update has no user or chat to key a conversation")
+
+var keyStrategy = KeyByUserInChat
+
+// SetKeyStrategy changes how conversations are keyed. It should be called
+// before any conversation is started, existing conversations are not
+// re-keyed.
+func SetKeyStrategy(s KeyStrategy) {
+	mu.Lock()
+	keyStrategy = s
+	mu.Unlock()
+}
+
+// threadId returns the forum topic of the update's message or 0
+func threadId(update telbot.Update) int {
+	if msg := update.EffectiveMessage(); msg != nil && msg.IsTopicMessage {
+		return msg.MessageThreadId
+	}
+	return 0
+}
+
+// keyFor returns the store key of the conversation an update belongs to
+func keyFor(update telbot.Update) (string, error) {
+	mu.RLock()
+	strategy := keyStrategy
+	mu.RUnlock()
+
+	user, chat := update.EffectiveUser(), update.EffectiveChat()
+	switch strategy {
+	case KeyByUser:
+		if user != nil {
+			return fmt.Sprintf("user:%d", user.Id), nil
+		}
+	case KeyByChat:
+		if chat != nil {
+			return fmt.Sprintf("chat:%d", chat.Id), nil
+		}
+	case KeyByTopic:
+		if chat != nil {
+			return fmt.Sprintf("topic:%d:%d", chat.Id, threadId(update)), nil
+		}
+	default:
+		if user != nil && chat != nil {
+			return fmt.Sprintf("member:%d:%d", chat.Id, user.Id), nil
+		}
+	}
+	return "", ErrNoConversationKey
+}
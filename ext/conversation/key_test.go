@@ -0,0 +1,110 @@
+package conversation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thehxdev/telbot"
+	"github.com/thehxdev/telbot/types"
+)
+
+func TestKeyFor(t *testing.T) {
+	group := &types.Chat{Id: -100, Type: "supergroup"}
+	channel := &types.Chat{Id: -200, Type: "channel"}
+	user := &types.User{Id: 7}
+
+	updates := map[string]telbot.Update{
+		"message":        {Message: &types.Message{Chat: group, From: user}},
+		"edited message": {EditedMessage: &types.Message{Chat: group, From: user}},
+		"callback query": {CallbackQuery: &types.CallbackQuery{
+			From: *user,
+			// the message of a callback query is sent by the bot
+			Message: &types.MaybeInaccessibleMessage{Message: &types.Message{Chat: group, From: &types.User{Id: 1}}},
+		}},
+		"channel post": {ChannelPost: &types.Message{Chat: channel}},
+		"topic message": {Message: &types.Message{
+			Chat: group, From: user, IsTopicMessage: true, MessageThreadId: 5,
+		}},
+		"inline query": {InlineQuery: &types.InlineQuery{From: *user}},
+	}
+
+	tests := []struct {
+		strategy KeyStrategy
+		want     map[string]string // empty for ErrNoConversationKey
+	}{
+		{KeyByUserInChat, map[string]string{
+			"message":        "member:-100:7",
+			"edited message": "member:-100:7",
+			"callback query": "member:-100:7",
+			"channel post":   "",
+			"topic message":  "member:-100:7",
+			"inline query":   "",
+		}},
+		{KeyByUser, map[string]string{
+			"message":        "user:7",
+			"edited message": "user:7",
+			"callback query": "user:7",
+			"channel post":   "",
+			"topic message":  "user:7",
+			"inline query":   "user:7",
+		}},
+		{KeyByChat, map[string]string{
+			"message":        "chat:-100",
+			"edited message": "chat:-100",
+			"callback query": "chat:-100",
+			"channel post":   "chat:-200",
+			"topic message":  "chat:-100",
+			"inline query":   "",
+		}},
+		{KeyByTopic, map[string]string{
+			"message":        "topic:-100:0",
+			"edited message": "topic:-100:0",
+			"callback query": "topic:-100:0",
+			"channel post":   "topic:-200:0",
+			"topic message":  "topic:-100:5",
+			"inline query":   "",
+		}},
+	}
+	for _, tt := range tests {
+		reset(t)
+		SetKeyStrategy(tt.strategy)
+		for name, update := range updates {
+			want, ok := tt.want[name]
+			if !ok {
+				t.Fatalf("strategy %d: no expectation for %s", tt.strategy, name)
+			}
+			key, err := keyFor(update)
+			if want == "" {
+				if !errors.Is(err, ErrNoConversationKey) {
+					t.Errorf("strategy %d, %s: keyFor = %q, %v, want ErrNoConversationKey", tt.strategy, name, key, err)
+				}
+				continue
+			}
+			if err != nil || key != want {
+				t.Errorf("strategy %d, %s: keyFor = %q, %v, want %q", tt.strategy, name, key, err, want)
+			}
+		}
+	}
+}
+
+func TestThreadId(t *testing.T) {
+	chat := &types.Chat{Id: 1}
+	tests := []struct {
+		name   string
+		update telbot.Update
+		want   int
+	}{
+		{"topic message", telbot.Update{Message: &types.Message{Chat: chat, IsTopicMessage: true, MessageThreadId: 5}}, 5},
+		// replies in ordinary groups have a thread id too
+		{"reply thread", telbot.Update{Message: &types.Message{Chat: chat, MessageThreadId: 5}}, 0},
+		{"edited topic message", telbot.Update{EditedMessage: &types.Message{Chat: chat, IsTopicMessage: true, MessageThreadId: 6}}, 6},
+		{"no message", telbot.Update{InlineQuery: &types.InlineQuery{}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := threadId(tt.update); got != tt.want {
+				t.Errorf("threadId = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package conversation
+
+// Transition helpers are meant to be returned from handlers, e.g.
+// `return c.Goto("age")`.
+
+// Goto moves the conversation to state and remembers the current state so
+// Back can return to it
+func (c *Conversation) Goto(state string) error {
+	c.History = append(c.History, c.State)
+	c.State = state
+	return nil
+}
+
+// Back returns to the previous state. The conversation stays in the
+// current state if there is no previous one.
+func (c *Conversation) Back() error {
+	if n := len(c.History); n > 0 {
+		c.State = c.History[n-1]
+		c.History = c.History[:n-1]
+	}
+	return nil
+}
+
+// Stay keeps the conversation in the current state, e.g. to ask again
+// after an invalid answer
+func (c *Conversation) Stay() error {
+	return nil
+}
+
+// End ends the conversation. result is passed to the OnEnd handler.
+func (c *Conversation) End(result any) error {
+	return &EndConversation{Result: result}
+}
@@ -0,0 +1,217 @@
+package conversation
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var stores = []struct {
+	name string
+	new  func(t *testing.T) ConversationStore
+}{
+	{"InMemory", func(t *testing.T) ConversationStore {
+		return NewDefaultConversationStore()
+	}},
+	{"File", func(t *testing.T) ConversationStore {
+		fs, err := NewFileConversationStore(filepath.Join(t.TempDir(), "conversations.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fs
+	}},
+}
+
+func newConversation(key string) *Conversation {
+	c := &Conversation{State: "start", Key: key, ChatId: 1, UserId: 2}
+	c.Goto("name")
+	Set(c, "name", "Ali")
+	return c
+}
+
+func TestStoreGetRemove(t *testing.T) {
+	for _, s := range stores {
+		t.Run(s.name, func(t *testing.T) {
+			store := s.new(t)
+
+			if _, err := store.Get("k"); !errors.Is(err, ErrConversationNotFound) {
+				t.Fatalf("Get of missing key: got %v, want ErrConversationNotFound", err)
+			}
+
+			if err := store.Store("k", newConversation("k")); err != nil {
+				t.Fatal(err)
+			}
+			conv, err := store.Get("k")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if conv.State != "name" || conv.Key != "k" || conv.ChatId != 1 || conv.UserId != 2 {
+				t.Errorf("unexpected conversation %+v", conv)
+			}
+			if len(conv.History) != 1 || conv.History[0] != "start" {
+				t.Errorf("History = %v, want [start]", conv.History)
+			}
+			if name, ok := Get[string](conv, "name"); !ok || name != "Ali" {
+				t.Errorf("Get(name) = %q, %v", name, ok)
+			}
+
+			if err := store.Remove("k"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.Get("k"); !errors.Is(err, ErrConversationNotFound) {
+				t.Fatalf("Get after Remove: got %v, want ErrConversationNotFound", err)
+			}
+			if err := store.Remove("k"); err != nil {
+				t.Fatalf("Remove of missing key: %v", err)
+			}
+		})
+	}
+}
+
+func TestStoreReturnsCopies(t *testing.T) {
+	for _, s := range stores {
+		t.Run(s.name, func(t *testing.T) {
+			store := s.new(t)
+			stored := newConversation("k")
+			if err := store.Store("k", stored); err != nil {
+				t.Fatal(err)
+			}
+			stored.State = "changed"
+
+			conv, err := store.Get("k")
+			if err != nil {
+				t.Fatal(err)
+			}
+			conv.Goto("age")
+			Set(conv, "name", "Reza")
+
+			conv, err = store.Get("k")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if conv.State != "name" || len(conv.History) != 1 {
+				t.Errorf("stored conversation was modified: %+v", conv)
+			}
+			if name, _ := Get[string](conv, "name"); name != "Ali" {
+				t.Errorf("stored data was modified: name = %q", name)
+			}
+		})
+	}
+}
+
+func TestStoreExpiry(t *testing.T) {
+	for _, s := range stores {
+		t.Run(s.name, func(t *testing.T) {
+			store := s.new(t)
+			now := time.Now()
+
+			expired := newConversation("old")
+			expired.ExpiresAt = now.Add(-time.Second)
+			active := newConversation("new")
+			active.ExpiresAt = now.Add(time.Hour)
+			forever := newConversation("forever")
+			for _, c := range []*Conversation{expired, active, forever} {
+				if err := store.Store(c.Key, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := store.Get("old"); !errors.Is(err, ErrConversationNotFound) {
+				t.Errorf("Get of expired conversation: got %v, want ErrConversationNotFound", err)
+			}
+			if _, err := store.Get("new"); err != nil {
+				t.Errorf("Get of active conversation: %v", err)
+			}
+
+			removed, err := store.RemoveExpired(now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(removed) != 1 || removed[0].Key != "old" {
+				t.Fatalf("RemoveExpired = %+v, want only \"old\"", removed)
+			}
+			removed, err = store.RemoveExpired(now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(removed) != 0 {
+				t.Errorf("second RemoveExpired = %+v, want none", removed)
+			}
+
+			removed, err = store.RemoveExpired(now.Add(2 * time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(removed) != 1 || removed[0].Key != "new" {
+				t.Errorf("RemoveExpired later = %+v, want only \"new\"", removed)
+			}
+			if _, err := store.Get("forever"); err != nil {
+				t.Errorf("conversation without ExpiresAt expired: %v", err)
+			}
+		})
+	}
+}
+
+func TestFileStoreReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conversations.json")
+	fs, err := NewFileConversationStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Store("k", newConversation("k")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Store("gone", newConversation("gone")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Remove("gone"); err != nil {
+		t.Fatal(err)
+	}
+
+	fs, err = NewFileConversationStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv, err := fs.Get("k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := Get[string](conv, "name"); conv.State != "name" || name != "Ali" {
+		t.Errorf("reloaded conversation %+v, name %q", conv, name)
+	}
+	if _, err := fs.Get("gone"); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("removed conversation was reloaded: %v", err)
+	}
+}
+
+func TestFileStoreCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conversations.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileConversationStore(path); err == nil {
+		t.Error("expected an error for a corrupted file")
+	}
+}
+
+func TestFileStoreRemoveExpiredCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conversations.json")
+	expired := `{"State": "name", "Key": "old", "ExpiresAt": "2000-01-01T00:00:00Z"}`
+	data := `{"old": ` + expired + `, "bad": 42}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fs, err := NewFileConversationStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fs.RemoveExpired(time.Now()); err == nil {
+		t.Fatal("expected an error for a corrupted conversation")
+	}
+	if _, ok := fs.table["old"]; !ok {
+		t.Error("expired conversation was removed although RemoveExpired failed")
+	}
+}
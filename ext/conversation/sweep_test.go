@@ -0,0 +1,133 @@
+package conversation
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thehxdev/telbot"
+)
+
+// recordTimeouts registers an OnTimeout handler that collects the keys of
+// timed out conversations
+func recordTimeouts() func() []string {
+	mu := sync.Mutex{}
+	keys := []string{}
+	OnTimeout(func(ctx context.Context, bot *telbot.Bot, c *Conversation) error {
+		mu.Lock()
+		keys = append(keys, c.Key)
+		mu.Unlock()
+		return nil
+	})
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		slices.Sort(keys)
+		return slices.Clone(keys)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	reset(t)
+	timeouts := recordTimeouts()
+	now := time.Now()
+	for key, expiresAt := range map[string]time.Time{
+		"a":       now.Add(-time.Minute),
+		"b":       now.Add(-time.Second),
+		"later":   now.Add(time.Hour),
+		"forever": {},
+	} {
+		if err := convStore.Store(key, &Conversation{Key: key, State: "s", ExpiresAt: expiresAt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sweep(context.Background(), nil, now)
+	if got := timeouts(); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("timed out %v, want [a b]", got)
+	}
+	sweep(context.Background(), nil, now)
+	if got := timeouts(); len(got) != 2 {
+		t.Errorf("conversations timed out twice: %v", got)
+	}
+	sweep(context.Background(), nil, now.Add(2*time.Hour))
+	if got := timeouts(); !slices.Equal(got, []string{"a", "b", "later"}) {
+		t.Errorf("timed out %v, want [a b later]", got)
+	}
+}
+
+func TestAnsweredConversationDoesNotTimeOut(t *testing.T) {
+	reset(t)
+	timeouts := recordTimeouts()
+	deadline := time.Now().Add(time.Second)
+	Handle("question", func(c *Conversation, u telbot.Update) error {
+		// the sweeper runs after the old deadline while the handler is busy
+		sweep(context.Background(), nil, deadline.Add(time.Second))
+		return c.Goto("answered")
+	})
+
+	key := "member:1:2"
+	conv := &Conversation{Key: key, State: "question", Timeout: time.Hour, ExpiresAt: deadline}
+	if err := convStore.Store(key, conv); err != nil {
+		t.Fatal(err)
+	}
+	if err := CallNext(message(1, 2, "answer")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := timeouts(); len(got) != 0 {
+		t.Errorf("answered conversation timed out: %v", got)
+	}
+	conv, err := convStore.Get(key)
+	if err != nil || conv.State != "answered" {
+		t.Errorf("Get = %+v, %v, want state \"answered\"", conv, err)
+	}
+}
+
+func TestExpiredDuringHandler(t *testing.T) {
+	reset(t)
+	timeouts := recordTimeouts()
+	Handle("start", func(c *Conversation, u telbot.Update) error {
+		c.ExpiresAt = time.Now().Add(-time.Second)
+		return c.Goto("next")
+	})
+	if err := Start("start", message(1, 2, "x")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the expired conversation is neither stored nor timed out later
+	sweep(context.Background(), nil, time.Now())
+	if HasConversation(message(1, 2, "x")) {
+		t.Error("expired conversation was stored")
+	}
+	if got := timeouts(); len(got) != 0 {
+		t.Errorf("timed out %v, want none", got)
+	}
+}
+
+func TestStartSweeper(t *testing.T) {
+	reset(t)
+	done := make(chan string, 1)
+	OnTimeout(func(ctx context.Context, bot *telbot.Bot, c *Conversation) error {
+		done <- c.Key
+		return nil
+	})
+	conv := &Conversation{Key: "k", State: "s", ExpiresAt: time.Now().Add(-time.Second)}
+	if err := convStore.Store("k", conv); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartSweeper(ctx, nil, 10*time.Millisecond)
+	select {
+	case key := <-done:
+		if key != "k" {
+			t.Errorf("timed out %q, want \"k\"", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("sweeper didn't time out the conversation")
+	}
+}
@@ -0,0 +1,175 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+
+	"github.com/thehxdev/telbot"
+)
+
+// Return ErrStopPropagation from a handler to prevent handlers in the
+// next groups from receiving the update.
+var ErrStopPropagation = errors.New("stop propagation")
+
+type Middleware func(next telbot.UpdateHandler) telbot.UpdateHandler
+
+type ErrorHandler func(update telbot.Update, err error)
+
+type route struct {
+	handler telbot.UpdateHandler
+	filters []Filter
+}
+
+func (r *route) match(update telbot.Update) bool {
+	for _, f := range r.filters {
+		if !f(update) {
+			return false
+		}
+	}
+	return true
+}
+
+// Dispatcher routes updates to registered handlers. Handlers are
+// organized in groups which are processed in ascending order. In each
+// group only the first handler whose filters match the update is called.
+// The zero value is ready to use with a single worker and no OnError.
+type Dispatcher struct {
+	// Number of goroutines handling updates concurrently
+	Workers int
+	// Called with errors returned by handlers and recovered panics
+	OnError ErrorHandler
+
+	mu          sync.RWMutex
+	middlewares []Middleware
+	groups      map[int][]route
+	groupOrder  []int
+}
+
+// Create a new Dispatcher with the given number of workers
+func New(workers int) *Dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Dispatcher{
+		Workers: workers,
+		OnError: func(update telbot.Update, err error) {
+			log.Printf("update %d: %v", update.Id, err)
+		},
+		groups: make(map[int][]route),
+	}
+}
+
+// Use appends middlewares to the chain. Middlewares wrap the routing of
+// every update, in the order they are added.
+func (d *Dispatcher) Use(mw ...Middleware) {
+	d.mu.Lock()
+	d.middlewares = append(d.middlewares, mw...)
+	d.mu.Unlock()
+}
+
+// Handle registers a handler in the default group (0)
+func (d *Dispatcher) Handle(handler telbot.UpdateHandler, filters ...Filter) {
+	d.HandleGroup(0, handler, filters...)
+}
+
+// HandleGroup registers a handler in a group. Groups with lower numbers
+// have higher priority.
+func (d *Dispatcher) HandleGroup(group int, handler telbot.UpdateHandler, filters ...Filter) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.groups == nil {
+		d.groups = make(map[int][]route)
+	}
+	if _, ok := d.groups[group]; !ok {
+		// never sort in place, route may be iterating the old slice
+		order := append(slices.Clone(d.groupOrder), group)
+		slices.Sort(order)
+		d.groupOrder = order
+	}
+	d.groups[group] = append(d.groups[group], route{
+		handler: handler,
+		filters: filters,
+	})
+}
+
+// Start consumes updates with a pool of workers. It blocks until the
+// channel is closed or ctx is done and all in-flight updates are handled.
+// At least one worker is started.
+func (d *Dispatcher) Start(ctx context.Context, updates <-chan telbot.Update) {
+	workers := max(d.Workers, 1)
+	wg := sync.WaitGroup{}
+	for range workers {
+		wg.Go(func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case update, ok := <-updates:
+					if !ok {
+						return
+					}
+					d.ProcessUpdate(update)
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// ProcessUpdate passes the update through the middleware chain and
+// handlers. Panics are recovered and reported to OnError.
+func (d *Dispatcher) ProcessUpdate(update telbot.Update) {
+	defer func() {
+		if r := recover(); r != nil {
+			d.reportError(update, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	d.mu.RLock()
+	handler := telbot.UpdateHandler(d.route)
+	for i := len(d.middlewares) - 1; i >= 0; i-- {
+		handler = d.middlewares[i](handler)
+	}
+	d.mu.RUnlock()
+
+	if err := handler(update); err != nil && !errors.Is(err, ErrStopPropagation) {
+		d.reportError(update, err)
+	}
+}
+
+func (d *Dispatcher) route(update telbot.Update) error {
+	// take a snapshot so handlers are able to register new handlers
+	d.mu.RLock()
+	groups := make([][]route, len(d.groupOrder))
+	for i, group := range d.groupOrder {
+		groups[i] = d.groups[group]
+	}
+	d.mu.RUnlock()
+
+	for _, routes := range groups {
+		for _, r := range routes {
+			if !r.match(update) {
+				continue
+			}
+			if err := r.handler(update); err != nil {
+				if errors.Is(err, ErrStopPropagation) {
+					return nil
+				}
+				// report the error and continue with the next group
+				d.reportError(update, err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) reportError(update telbot.Update, err error) {
+	if d.OnError != nil {
+		d.OnError(update, err)
+	}
+}
@@ -0,0 +1,186 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thehxdev/telbot"
+	"github.com/thehxdev/telbot/types"
+)
+
+func textUpdate(text string) telbot.Update {
+	return telbot.Update{Id: 1, Message: &types.Message{Text: text, Chat: &types.Chat{Id: 1, Type: "private"}}}
+}
+
+// record returns a handler that appends name to calls and returns err
+func record(calls *[]string, name string, err error) telbot.UpdateHandler {
+	return func(update telbot.Update) error {
+		*calls = append(*calls, name)
+		return err
+	}
+}
+
+func TestFirstMatchWins(t *testing.T) {
+	d := &Dispatcher{}
+	calls := []string{}
+	d.Handle(record(&calls, "hello", nil), Text("hello"))
+	d.Handle(record(&calls, "first", nil), All)
+	d.Handle(record(&calls, "second", nil), All)
+
+	d.ProcessUpdate(textUpdate("hello"))
+	d.ProcessUpdate(textUpdate("other"))
+	if want := []string{"hello", "first"}; !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestGroupOrder(t *testing.T) {
+	d := &Dispatcher{}
+	calls := []string{}
+	d.HandleGroup(10, record(&calls, "10", nil), All)
+	d.HandleGroup(-1, record(&calls, "-1", nil), All)
+	d.HandleGroup(0, record(&calls, "0", errors.New("failed")), All)
+	d.HandleGroup(5, record(&calls, "5", nil), Text("no match"))
+
+	errs := []error{}
+	d.OnError = func(update telbot.Update, err error) {
+		errs = append(errs, err)
+	}
+	d.ProcessUpdate(textUpdate("x"))
+	if want := []string{"-1", "0", "10"}; !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if len(errs) != 1 || errs[0].Error() != "failed" {
+		t.Errorf("reported errors = %v, want [failed]", errs)
+	}
+}
+
+func TestStopPropagation(t *testing.T) {
+	d := &Dispatcher{}
+	calls := []string{}
+	d.HandleGroup(0, record(&calls, "0", ErrStopPropagation), All)
+	d.HandleGroup(1, record(&calls, "1", nil), All)
+
+	reported := false
+	d.OnError = func(update telbot.Update, err error) {
+		reported = true
+	}
+	d.ProcessUpdate(textUpdate("x"))
+	if want := []string{"0"}; !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if reported {
+		t.Error("ErrStopPropagation was reported to OnError")
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	d := &Dispatcher{}
+	calls := []string{}
+	mw := func(name string) Middleware {
+		return func(next telbot.UpdateHandler) telbot.UpdateHandler {
+			return func(update telbot.Update) error {
+				calls = append(calls, name+" before")
+				err := next(update)
+				calls = append(calls, name+" after")
+				return err
+			}
+		}
+	}
+	d.Use(mw("outer"))
+	d.Use(mw("inner"))
+	d.Handle(record(&calls, "handler", nil), All)
+
+	d.ProcessUpdate(textUpdate("x"))
+	want := []string{"outer before", "inner before", "handler", "inner after", "outer after"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestPanicRecovered(t *testing.T) {
+	d := &Dispatcher{}
+	d.Handle(func(update telbot.Update) error {
+		panic("boom")
+	}, All)
+
+	var got error
+	d.OnError = func(update telbot.Update, err error) {
+		got = err
+	}
+	d.ProcessUpdate(textUpdate("x"))
+	if got == nil || !strings.Contains(got.Error(), "boom") {
+		t.Errorf("OnError got %v, want the recovered panic", got)
+	}
+}
+
+func TestStartWithoutWorkers(t *testing.T) {
+	d := &Dispatcher{}
+	mu := sync.Mutex{}
+	handled := 0
+	d.Handle(func(update telbot.Update) error {
+		mu.Lock()
+		handled++
+		mu.Unlock()
+		return nil
+	}, All)
+
+	updates := make(chan telbot.Update, 3)
+	for range 3 {
+		updates <- textUpdate("x")
+	}
+	close(updates)
+
+	done := make(chan struct{})
+	go func() {
+		d.Start(context.Background(), updates)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Start didn't return after the channel was closed")
+	}
+	if handled != 3 {
+		t.Errorf("handled %d updates, want 3", handled)
+	}
+}
+
+func TestCommandFilter(t *testing.T) {
+	command := func(text string) telbot.Update {
+		u := textUpdate(text)
+		end := strings.IndexByte(text+" ", ' ')
+		u.Message.Entities = []types.MessageEntity{{Type: "bot_command", Offset: 0, Length: end}}
+		return u
+	}
+	bot := &telbot.Bot{Self: &types.User{Username: "my_bot"}}
+	tests := []struct {
+		name   string
+		update telbot.Update
+		bot    *telbot.Bot
+		want   bool
+	}{
+		{"plain", command("/start"), bot, true},
+		{"with args", command("/start now"), bot, true},
+		{"addressed to this bot", command("/start@my_bot"), bot, true},
+		{"addressed to another bot", command("/start@OtherBot"), bot, false},
+		{"another bot without Self", command("/start@OtherBot"), nil, true},
+		{"other command", command("/help"), bot, false},
+		{"not a command", textUpdate("start"), bot, false},
+		{"no message", telbot.Update{CallbackQuery: &types.CallbackQuery{Data: "/start"}}, bot, false},
+	}
+	filter := Command("start")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.update.Bot = tt.bot
+			if got := filter(tt.update); got != tt.want {
+				t.Errorf("Command(start) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
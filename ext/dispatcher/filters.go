@@ -0,0 +1,116 @@
+package dispatcher
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/thehxdev/telbot"
+)
+
+type Filter func(update telbot.Update) bool
+
+func All(update telbot.Update) bool {
+	return true
+}
+
+func And(filters ...Filter) Filter {
+	return func(update telbot.Update) bool {
+		for _, f := range filters {
+			if !f(update) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func Or(filters ...Filter) Filter {
+	return func(update telbot.Update) bool {
+		for _, f := range filters {
+			if f(update) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func Not(filter Filter) Filter {
+	return func(update telbot.Update) bool {
+		return !filter(update)
+	}
+}
+
+// Kind matches updates of the given kinds
+func Kind(kinds ...telbot.UpdateKind) Filter {
+	return func(update telbot.Update) bool {
+		return slices.Contains(kinds, update.Kind())
+	}
+}
+
+func IsMessage(update telbot.Update) bool {
+	return update.Message != nil
+}
+
+func IsEditedMessage(update telbot.Update) bool {
+	return update.EditedMessage != nil
+}
+
+func IsChannelPost(update telbot.Update) bool {
+	return update.ChannelPost != nil
+}
+
+func IsCallbackQuery(update telbot.Update) bool {
+	return update.CallbackQuery != nil
+}
+
+func IsInlineQuery(update telbot.Update) bool {
+	return update.InlineQuery != nil
+}
+
+// Command matches messages starting with one of the commands (without
+// the leading slash). Commands addressed to other bots are ignored.
+func Command(commands ...string) Filter {
+	return func(update telbot.Update) bool {
+		if update.Message == nil {
+			return false
+		}
+		cmd, ok := update.Message.Command()
+		if update.Bot != nil && update.Bot.Self != nil {
+			cmd, ok = update.Message.CommandFor(update.Bot.Self.Username)
+		}
+		return ok && slices.Contains(commands, cmd)
+	}
+}
+
+// Text matches messages with exactly the given text
+func Text(text string) Filter {
+	return func(update telbot.Update) bool {
+		return update.Message != nil && update.Message.Text == text
+	}
+}
+
+// Regex matches messages whose text matches the pattern. It panics if
+// the pattern can't be compiled.
+func Regex(pattern string) Filter {
+	re := regexp.MustCompile(pattern)
+	return func(update telbot.Update) bool {
+		return update.Message != nil && re.MatchString(update.Message.Text)
+	}
+}
+
+// CallbackPrefix matches callback queries whose data starts with prefix
+func CallbackPrefix(prefix string) Filter {
+	return func(update telbot.Update) bool {
+		return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, prefix)
+	}
+}
+
+// ChatType matches updates that belong to one of the chat types
+func ChatType(chatTypes ...string) Filter {
+	return func(update telbot.Update) bool {
+		chatType := update.ChatType()
+		return chatType != "" && slices.Contains(chatTypes, chatType)
+	}
+}
@@ -0,0 +1,137 @@
+package keyboard
+
+import (
+	"fmt"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+const (
+	maxCallbackDataSize = 64
+	maxInlineRowSize    = 8
+	maxInlineButtons    = 100
+)
+
+type InlineBuilder struct {
+	rows [][]types.InlineKeyboardButton
+}
+
+// Create a new inline keyboard builder
+func NewInline() *InlineBuilder {
+	return &InlineBuilder{}
+}
+
+// Row appends a row of buttons
+func (ib *InlineBuilder) Row(buttons ...types.InlineKeyboardButton) *InlineBuilder {
+	ib.rows = append(ib.rows, buttons)
+	return ib
+}
+
+// Column appends each button in a separate row
+func (ib *InlineBuilder) Column(buttons ...types.InlineKeyboardButton) *InlineBuilder {
+	for _, button := range buttons {
+		ib.rows = append(ib.rows, []types.InlineKeyboardButton{button})
+	}
+	return ib
+}
+
+// Build validates the keyboard against telegram limits and returns the
+// markup ready to be used as ReplyMarkup.
+func (ib *InlineBuilder) Build() (*types.InlineKeyboardMarkup, error) {
+	if len(ib.rows) == 0 {
+		return nil, fmt.Errorf("keyboard has no buttons")
+	}
+	count := 0
+	for i, row := range ib.rows {
+		if len(row) == 0 {
+			return nil, fmt.Errorf("row %d has no buttons", i)
+		}
+		if len(row) > maxInlineRowSize {
+			return nil, fmt.Errorf("row %d has %d buttons, at most %d allowed", i, len(row), maxInlineRowSize)
+		}
+		for j, button := range row {
+			if err := validateInlineButton(button); err != nil {
+				return nil, fmt.Errorf("button %d in row %d: %w", j, i, err)
+			}
+		}
+		count += len(row)
+	}
+	if count > maxInlineButtons {
+		return nil, fmt.Errorf("keyboard has %d buttons, at most %d allowed", count, maxInlineButtons)
+	}
+	return &types.InlineKeyboardMarkup{InlineKeyboard: ib.rows}, nil
+}
+
+func validateInlineButton(b types.InlineKeyboardButton) error {
+	if b.Text == "" {
+		return fmt.Errorf("text is empty")
+	}
+	if len(b.CallbackData) > maxCallbackDataSize {
+		return fmt.Errorf("callback data is %d bytes, at most %d allowed", len(b.CallbackData), maxCallbackDataSize)
+	}
+
+	// exactly one of the optional fields must be used
+	set := 0
+	for _, ok := range []bool{
+		b.Url != "",
+		b.CallbackData != "",
+		b.WebApp != nil,
+		b.LoginUrl != nil,
+		b.SwitchInlineQuery != nil,
+		b.SwitchInlineQueryCurrentChat != nil,
+		b.SwitchInlineQueryChosenChat != nil,
+		b.CopyText != nil,
+		b.CallbackGame != nil,
+		b.Pay,
+	} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("exactly one action must be set, got %d", set)
+	}
+	return nil
+}
+
+func Callback(text, data string) types.InlineKeyboardButton {
+	return types.InlineKeyboardButton{Text: text, CallbackData: data}
+}
+
+func Url(text, url string) types.InlineKeyboardButton {
+	return types.InlineKeyboardButton{Text: text, Url: url}
+}
+
+func WebApp(text, url string) types.InlineKeyboardButton {
+	return types.InlineKeyboardButton{Text: text, WebApp: &types.WebAppInfo{Url: url}}
+}
+
+func Login(text string, loginUrl types.LoginUrl) types.InlineKeyboardButton {
+	return types.InlineKeyboardButton{Text: text, LoginUrl: &loginUrl}
+}
+
+// SwitchInline prompts the user to select a chat and inserts the bot's
+// username and query in the input field
+func SwitchInline(text, query string) types.InlineKeyboardButton {
+	return types.InlineKeyboardButton{Text: text, SwitchInlineQuery: &query}
+}
+
+// SwitchInlineCurrentChat inserts the bot's username and query in the
+// input field of the current chat
+func SwitchInlineCurrentChat(text, query string) types.InlineKeyboardButton {
+	return types.InlineKeyboardButton{Text: text, SwitchInlineQueryCurrentChat: &query}
+}
+
+func SwitchInlineChosenChat(text string, chosen types.SwitchInlineQueryChosenChat) types.InlineKeyboardButton {
+	return types.InlineKeyboardButton{Text: text, SwitchInlineQueryChosenChat: &chosen}
+}
+
+func CopyText(text, copied string) types.InlineKeyboardButton {
+	return types.InlineKeyboardButton{Text: text, CopyText: &types.CopyTextButton{Text: copied}}
+}
+
+// Pay button must be the first button of the first row and can only be
+// used in invoice messages
+func Pay(text string) types.InlineKeyboardButton {
+	return types.InlineKeyboardButton{Text: text, Pay: true}
+}
@@ -0,0 +1,110 @@
+package keyboard
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+func callbacks(n int) []types.InlineKeyboardButton {
+	buttons := make([]types.InlineKeyboardButton, n)
+	for i := range buttons {
+		buttons[i] = Callback(strconv.Itoa(i), strconv.Itoa(i))
+	}
+	return buttons
+}
+
+func buttons(n int) []types.KeyboardButton {
+	buttons := make([]types.KeyboardButton, n)
+	for i := range buttons {
+		buttons[i] = Button(strconv.Itoa(i))
+	}
+	return buttons
+}
+
+func TestInlineBuild(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *InlineBuilder
+		wantErr string
+	}{
+		{"valid", NewInline().Row(Callback("a", "1"), Url("b", "https://example.com")).Column(Pay("pay"), CopyText("c", "x")), ""},
+		{"full rows", NewInline().Row(callbacks(8)...).Column(callbacks(92)...), ""},
+		{"64 byte callback data", NewInline().Row(Callback("a", strings.Repeat("x", 64))), ""},
+		{"no rows", NewInline(), "no buttons"},
+		{"empty row", NewInline().Row(Callback("a", "1")).Row(), "row 1 has no buttons"},
+		{"row too long", NewInline().Row(callbacks(9)...), "row 0 has 9 buttons"},
+		{"too many buttons", NewInline().Column(callbacks(101)...), "keyboard has 101 buttons"},
+		{"callback data too long", NewInline().Row(Callback("a", strings.Repeat("x", 65))), "callback data is 65 bytes"},
+		{"multibyte callback data", NewInline().Row(Callback("a", strings.Repeat("ж", 33))), "callback data is 66 bytes"},
+		{"empty text", NewInline().Row(Callback("", "1")), "text is empty"},
+		{"no action", NewInline().Row(types.InlineKeyboardButton{Text: "a"}), "got 0"},
+		{"two actions", NewInline().Row(types.InlineKeyboardButton{Text: "a", Url: "https://example.com", CallbackData: "1"}), "got 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markup, err := tt.builder.Build()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Build() = %v", err)
+				}
+				if len(markup.InlineKeyboard) == 0 {
+					t.Error("Build() returned an empty keyboard")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReplyBuild(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *ReplyBuilder
+		wantErr string
+	}{
+		{"valid", NewReply().Row(Button("a"), ContactButton("b")).Column(LocationButton("c")).Resize().OneTime(), ""},
+		{"full rows", NewReply().Row(buttons(12)...).Column(buttons(288)...), ""},
+		{"64 character placeholder", NewReply().Row(Button("a")).Placeholder(strings.Repeat("ж", 64)), ""},
+		{"no rows", NewReply(), "no buttons"},
+		{"empty row", NewReply().Row(), "row 0 has no buttons"},
+		{"row too long", NewReply().Row(buttons(13)...), "row 0 has 13 buttons"},
+		{"too many buttons", NewReply().Column(buttons(301)...), "keyboard has 301 buttons"},
+		{"empty text", NewReply().Row(Button("a"), Button("")), "button 1 in row 0: text is empty"},
+		{"placeholder too long", NewReply().Row(Button("a")).Placeholder(strings.Repeat("x", 65)), "placeholder"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markup, err := tt.builder.Build()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Build() = %v", err)
+				}
+				if len(markup.Keyboard) == 0 {
+					t.Error("Build() returned an empty keyboard")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReplyBuildCopiesMarkup(t *testing.T) {
+	rb := NewReply().Row(Button("a")).Resize()
+	markup, err := rb.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rb.Placeholder("changed")
+	if markup.InputFieldPlaceholder != "" || !markup.ResizeKeyboard {
+		t.Errorf("unexpected markup %+v", markup)
+	}
+}
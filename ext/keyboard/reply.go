@@ -0,0 +1,139 @@
+package keyboard
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+const (
+	maxReplyRowSize = 12
+	maxReplyButtons = 300
+	maxPlaceholder  = 64
+)
+
+type ReplyBuilder struct {
+	markup types.ReplyKeyboardMarkup
+}
+
+// Create a new reply keyboard builder
+func NewReply() *ReplyBuilder {
+	return &ReplyBuilder{}
+}
+
+// Row appends a row of buttons
+func (rb *ReplyBuilder) Row(buttons ...types.KeyboardButton) *ReplyBuilder {
+	rb.markup.Keyboard = append(rb.markup.Keyboard, buttons)
+	return rb
+}
+
+// Column appends each button in a separate row
+func (rb *ReplyBuilder) Column(buttons ...types.KeyboardButton) *ReplyBuilder {
+	for _, button := range buttons {
+		rb.markup.Keyboard = append(rb.markup.Keyboard, []types.KeyboardButton{button})
+	}
+	return rb
+}
+
+func (rb *ReplyBuilder) Persistent() *ReplyBuilder {
+	rb.markup.IsPersistent = true
+	return rb
+}
+
+func (rb *ReplyBuilder) Resize() *ReplyBuilder {
+	rb.markup.ResizeKeyboard = true
+	return rb
+}
+
+func (rb *ReplyBuilder) OneTime() *ReplyBuilder {
+	rb.markup.OneTimeKeyboard = true
+	return rb
+}
+
+func (rb *ReplyBuilder) Selective() *ReplyBuilder {
+	rb.markup.Selective = true
+	return rb
+}
+
+func (rb *ReplyBuilder) Placeholder(text string) *ReplyBuilder {
+	rb.markup.InputFieldPlaceholder = text
+	return rb
+}
+
+// Build validates the keyboard against telegram limits and returns the
+// markup ready to be used as ReplyMarkup.
+func (rb *ReplyBuilder) Build() (*types.ReplyKeyboardMarkup, error) {
+	rows := rb.markup.Keyboard
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("keyboard has no buttons")
+	}
+	if utf8.RuneCountInString(rb.markup.InputFieldPlaceholder) > maxPlaceholder {
+		return nil, fmt.Errorf("input field placeholder is longer than %d characters", maxPlaceholder)
+	}
+	count := 0
+	for i, row := range rows {
+		if len(row) == 0 {
+			return nil, fmt.Errorf("row %d has no buttons", i)
+		}
+		if len(row) > maxReplyRowSize {
+			return nil, fmt.Errorf("row %d has %d buttons, at most %d allowed", i, len(row), maxReplyRowSize)
+		}
+		for j, button := range row {
+			if button.Text == "" {
+				return nil, fmt.Errorf("button %d in row %d: text is empty", j, i)
+			}
+		}
+		count += len(row)
+	}
+	if count > maxReplyButtons {
+		return nil, fmt.Errorf("keyboard has %d buttons, at most %d allowed", count, maxReplyButtons)
+	}
+	markup := rb.markup
+	return &markup, nil
+}
+
+func Button(text string) types.KeyboardButton {
+	return types.KeyboardButton{Text: text}
+}
+
+// ContactButton sends the user's phone number when pressed. Only
+// available in private chats.
+func ContactButton(text string) types.KeyboardButton {
+	return types.KeyboardButton{Text: text, RequestContact: true}
+}
+
+// LocationButton sends the user's location when pressed. Only
+// available in private chats.
+func LocationButton(text string) types.KeyboardButton {
+	return types.KeyboardButton{Text: text, RequestLocation: true}
+}
+
+// PollButton asks the user to create a poll of pollType ("quiz",
+// "regular" or empty for any type)
+func PollButton(text, pollType string) types.KeyboardButton {
+	return types.KeyboardButton{Text: text, RequestPoll: &types.KeyboardButtonPollType{Type: pollType}}
+}
+
+func UsersButton(text string, request types.KeyboardButtonRequestUsers) types.KeyboardButton {
+	return types.KeyboardButton{Text: text, RequestUsers: &request}
+}
+
+func ChatButton(text string, request types.KeyboardButtonRequestChat) types.KeyboardButton {
+	return types.KeyboardButton{Text: text, RequestChat: &request}
+}
+
+func WebAppButton(text, url string) types.KeyboardButton {
+	return types.KeyboardButton{Text: text, WebApp: &types.WebAppInfo{Url: url}}
+}
+
+// Remove returns the markup that hides the current reply keyboard
+func Remove() *types.ReplyKeyboardRemove {
+	return &types.ReplyKeyboardRemove{}
+}
+
+// ForceReply returns the markup that opens a reply interface to the
+// bot's message
+func ForceReply(placeholder string) *types.ForceReply {
+	return &types.ForceReply{InputFieldPlaceholder: placeholder}
+}
@@ -0,0 +1,66 @@
+package format
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+// Formatted is plain text with entities, ready to be used as `Text` and
+// `Entities` of message parameters
+type Formatted struct {
+	Text     string
+	Entities []types.MessageEntity
+}
+
+// Build converts nodes to plain text and entities. Offsets and lengths
+// are counted in UTF-16 code units as required by telegram.
+func Build(nodes ...Node) Formatted {
+	sb := strings.Builder{}
+	entities := []types.MessageEntity{}
+	offset := 0
+	for _, n := range nodes {
+		offset = build(n, &sb, &entities, offset)
+	}
+	SortEntities(entities)
+	return Formatted{Text: sb.String(), Entities: entities}
+}
+
+func build(n Node, sb *strings.Builder, entities *[]types.MessageEntity, offset int) int {
+	if n.entity.Type == "" {
+		sb.WriteString(n.text)
+		return offset + types.UTF16Len(n.text)
+	}
+	start := offset
+	// the entity goes before the entities of its children
+	idx := len(*entities)
+	for _, child := range n.children {
+		offset = build(child, sb, entities, offset)
+	}
+	if offset > start {
+		e := n.entity
+		e.Offset = start
+		e.Length = offset - start
+		*entities = slices.Insert(*entities, idx, e)
+	}
+	return offset
+}
+
+// SortEntities sorts entities by offset. Entities starting at the same
+// offset are ordered from the outermost (longest) to the innermost.
+func SortEntities(entities []types.MessageEntity) {
+	slices.SortStableFunc(entities, func(a, b types.MessageEntity) int {
+		if a.Offset != b.Offset {
+			return a.Offset - b.Offset
+		}
+		return b.Length - a.Length
+	})
+}
+
+// sortClosed sorts entities collected in the order they were closed. Outer
+// entities close after inner ones with the same range but must come first.
+func sortClosed(entities []types.MessageEntity) {
+	slices.Reverse(entities)
+	SortEntities(entities)
+}
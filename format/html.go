@@ -0,0 +1,69 @@
+package format
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// EscapeHTML escapes "<", ">", "&" and quotes
+func EscapeHTML(s string) string {
+	return html.EscapeString(s)
+}
+
+// HTML renders nodes as text for the HTML parse mode
+func HTML(nodes ...Node) string {
+	sb := strings.Builder{}
+	for _, n := range nodes {
+		sb.WriteString(htmlNode(n))
+	}
+	return sb.String()
+}
+
+func htmlChildren(n Node) string {
+	sb := strings.Builder{}
+	for _, child := range n.children {
+		sb.WriteString(htmlNode(child))
+	}
+	return sb.String()
+}
+
+func htmlNode(n Node) string {
+	e := n.entity
+	switch e.Type {
+	case "":
+		return EscapeHTML(n.text)
+	case entityBold:
+		return "<b>" + htmlChildren(n) + "</b>"
+	case entityItalic:
+		return "<i>" + htmlChildren(n) + "</i>"
+	case entityUnderline:
+		return "<u>" + htmlChildren(n) + "</u>"
+	case entityStrikethrough:
+		return "<s>" + htmlChildren(n) + "</s>"
+	case entitySpoiler:
+		return "<tg-spoiler>" + htmlChildren(n) + "</tg-spoiler>"
+	case entityCode:
+		return "<code>" + EscapeHTML(plainChildren(n)) + "</code>"
+	case entityPre:
+		if e.Language != "" {
+			return `<pre><code class="language-` + EscapeHTML(e.Language) + `">` +
+				EscapeHTML(plainChildren(n)) + "</code></pre>"
+		}
+		return "<pre>" + EscapeHTML(plainChildren(n)) + "</pre>"
+	case entityTextLink:
+		return `<a href="` + EscapeHTML(e.Url) + `">` + htmlChildren(n) + "</a>"
+	case entityTextMention:
+		// mentions without a user are rendered as plain text
+		if e.User != nil {
+			return `<a href="tg://user?id=` + strconv.Itoa(e.User.Id) + `">` + htmlChildren(n) + "</a>"
+		}
+	case entityCustomEmoji:
+		return `<tg-emoji emoji-id="` + EscapeHTML(e.CustomEmojiId) + `">` + htmlChildren(n) + "</tg-emoji>"
+	case entityBlockquote:
+		return "<blockquote>" + htmlChildren(n) + "</blockquote>"
+	case entityExpandableBlockquote:
+		return "<blockquote expandable>" + htmlChildren(n) + "</blockquote>"
+	}
+	return htmlChildren(n)
+}
@@ -0,0 +1,92 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	markdownSpecial = "_*[]()~`>#+-=|{}.!\\"
+	markdownCode    = "`\\"
+	markdownUrl     = ")\\"
+)
+
+func escape(s, chars string) string {
+	sb := strings.Builder{}
+	for _, r := range s {
+		if strings.ContainsRune(chars, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// EscapeMarkdownV2 escapes all characters that have a meaning in
+// MarkdownV2
+func EscapeMarkdownV2(s string) string {
+	return escape(s, markdownSpecial)
+}
+
+// MarkdownV2 renders nodes as text for the MarkdownV2 parse mode
+func MarkdownV2(nodes ...Node) string {
+	sb := strings.Builder{}
+	for _, n := range nodes {
+		sb.WriteString(markdownNode(n))
+	}
+	return sb.String()
+}
+
+func markdownChildren(n Node) string {
+	sb := strings.Builder{}
+	for _, child := range n.children {
+		sb.WriteString(markdownNode(child))
+	}
+	return sb.String()
+}
+
+// plain text of the children, used by entities that can't be nested
+func plainChildren(n Node) string {
+	return Build(n.children...).Text
+}
+
+func markdownNode(n Node) string {
+	e := n.entity
+	switch e.Type {
+	case "":
+		return EscapeMarkdownV2(n.text)
+	case entityBold:
+		return "*" + markdownChildren(n) + "*"
+	case entityItalic:
+		content := markdownChildren(n)
+		// "___" is ambiguous, \r separates italic from underline
+		if strings.HasSuffix(content, "__") {
+			return "_" + content + "_\r"
+		}
+		return "_" + content + "_"
+	case entityUnderline:
+		return "__" + markdownChildren(n) + "__"
+	case entityStrikethrough:
+		return "~" + markdownChildren(n) + "~"
+	case entitySpoiler:
+		return "||" + markdownChildren(n) + "||"
+	case entityCode:
+		return "`" + escape(plainChildren(n), markdownCode) + "`"
+	case entityPre:
+		return "```" + e.Language + "\n" + escape(plainChildren(n), markdownCode) + "\n```"
+	case entityTextLink:
+		return "[" + markdownChildren(n) + "](" + escape(e.Url, markdownUrl) + ")"
+	case entityTextMention:
+		// mentions without a user are rendered as plain text
+		if e.User != nil {
+			return "[" + markdownChildren(n) + "](tg://user?id=" + strconv.Itoa(e.User.Id) + ")"
+		}
+	case entityCustomEmoji:
+		return "![" + markdownChildren(n) + "](tg://emoji?id=" + e.CustomEmojiId + ")"
+	case entityBlockquote:
+		return ">" + strings.ReplaceAll(markdownChildren(n), "\n", "\n>")
+	case entityExpandableBlockquote:
+		return "**>" + strings.ReplaceAll(markdownChildren(n), "\n", "\n>") + "||"
+	}
+	return markdownChildren(n)
+}
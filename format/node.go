@@ -0,0 +1,174 @@
+package format
+
+import (
+	"github.com/thehxdev/telbot/types"
+)
+
+const (
+	entityBold                 = "bold"
+	entityItalic               = "italic"
+	entityUnderline            = "underline"
+	entityStrikethrough        = "strikethrough"
+	entitySpoiler              = "spoiler"
+	entityCode                 = "code"
+	entityPre                  = "pre"
+	entityTextLink             = "text_link"
+	entityTextMention          = "text_mention"
+	entityCustomEmoji          = "custom_emoji"
+	entityBlockquote           = "blockquote"
+	entityExpandableBlockquote = "expandable_blockquote"
+)
+
+// Node is a piece of formatted text. Plain text nodes have an empty
+// type, other nodes wrap their children with an entity.
+type Node struct {
+	entity   types.MessageEntity
+	text     string
+	children []Node
+}
+
+func Text(text string) Node {
+	return Node{text: text}
+}
+
+func wrap(entityType string, children []Node) Node {
+	return Node{entity: types.MessageEntity{Type: entityType}, children: children}
+}
+
+func Bold(children ...Node) Node {
+	return wrap(entityBold, children)
+}
+
+func Italic(children ...Node) Node {
+	return wrap(entityItalic, children)
+}
+
+func Underline(children ...Node) Node {
+	return wrap(entityUnderline, children)
+}
+
+func Strikethrough(children ...Node) Node {
+	return wrap(entityStrikethrough, children)
+}
+
+func Spoiler(children ...Node) Node {
+	return wrap(entitySpoiler, children)
+}
+
+func Blockquote(children ...Node) Node {
+	return wrap(entityBlockquote, children)
+}
+
+// ExpandableBlockquote is collapsed by default
+func ExpandableBlockquote(children ...Node) Node {
+	return wrap(entityExpandableBlockquote, children)
+}
+
+// Code is monospaced inline text. It can't contain other entities.
+func Code(text string) Node {
+	return wrap(entityCode, []Node{Text(text)})
+}
+
+// Pre is a monospaced block with optional syntax highlighting language
+func Pre(language, text string) Node {
+	n := wrap(entityPre, []Node{Text(text)})
+	n.entity.Language = language
+	return n
+}
+
+func Link(url string, children ...Node) Node {
+	n := wrap(entityTextLink, children)
+	n.entity.Url = url
+	return n
+}
+
+// Mention links to a user, even if they have no username
+func Mention(user types.User, children ...Node) Node {
+	n := wrap(entityTextMention, children)
+	n.entity.User = &user
+	return n
+}
+
+// CustomEmoji shows the custom emoji with emojiId instead of emoji.
+// Only bots that purchased a username can use custom emojis.
+func CustomEmoji(emojiId, emoji string) Node {
+	n := wrap(entityCustomEmoji, []Node{Text(emoji)})
+	n.entity.CustomEmojiId = emojiId
+	return n
+}
+
+// Builder accumulates formatted nodes
+type Builder struct {
+	nodes []Node
+}
+
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Add appends nodes, use it to append nested formatting
+func (b *Builder) Add(nodes ...Node) *Builder {
+	b.nodes = append(b.nodes, nodes...)
+	return b
+}
+
+func (b *Builder) Text(text string) *Builder {
+	return b.Add(Text(text))
+}
+
+func (b *Builder) Bold(text string) *Builder {
+	return b.Add(Bold(Text(text)))
+}
+
+func (b *Builder) Italic(text string) *Builder {
+	return b.Add(Italic(Text(text)))
+}
+
+func (b *Builder) Underline(text string) *Builder {
+	return b.Add(Underline(Text(text)))
+}
+
+func (b *Builder) Strikethrough(text string) *Builder {
+	return b.Add(Strikethrough(Text(text)))
+}
+
+func (b *Builder) Spoiler(text string) *Builder {
+	return b.Add(Spoiler(Text(text)))
+}
+
+func (b *Builder) Blockquote(text string) *Builder {
+	return b.Add(Blockquote(Text(text)))
+}
+
+func (b *Builder) Code(text string) *Builder {
+	return b.Add(Code(text))
+}
+
+func (b *Builder) Pre(language, text string) *Builder {
+	return b.Add(Pre(language, text))
+}
+
+func (b *Builder) Link(url, text string) *Builder {
+	return b.Add(Link(url, Text(text)))
+}
+
+func (b *Builder) Mention(user types.User, text string) *Builder {
+	return b.Add(Mention(user, Text(text)))
+}
+
+func (b *Builder) CustomEmoji(emojiId, emoji string) *Builder {
+	return b.Add(CustomEmoji(emojiId, emoji))
+}
+
+// Build returns the plain text and its entities
+func (b *Builder) Build() Formatted {
+	return Build(b.nodes...)
+}
+
+func (b *Builder) MarkdownV2() string {
+	return MarkdownV2(b.nodes...)
+}
+
+func (b *Builder) HTML() string {
+	return HTML(b.nodes...)
+}
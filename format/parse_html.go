@@ -0,0 +1,129 @@
+package format
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+var htmlAttrRegex = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9-]*)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+
+type openTag struct {
+	name   string
+	entity types.MessageEntity
+	// false for tags that don't produce an entity (e.g. code inside pre)
+	emit bool
+}
+
+// ParseHTML parses text formatted with the HTML parse mode into plain
+// text and entities.
+func ParseHTML(s string) (Formatted, error) {
+	sb := strings.Builder{}
+	entities := []types.MessageEntity{}
+	stack := []openTag{}
+	offset := 0
+
+	for len(s) > 0 {
+		lt := strings.IndexByte(s, '<')
+		if lt == -1 {
+			lt = len(s)
+		}
+		if lt > 0 {
+			text := html.UnescapeString(s[:lt])
+			sb.WriteString(text)
+			offset += types.UTF16Len(text)
+			s = s[lt:]
+			continue
+		}
+
+		gt := strings.IndexByte(s, '>')
+		if gt == -1 {
+			return Formatted{}, fmt.Errorf("unclosed tag at %q", s)
+		}
+		tag := strings.TrimSpace(s[1:gt])
+		s = s[gt+1:]
+
+		if closing, ok := strings.CutPrefix(tag, "/"); ok {
+			name := strings.ToLower(strings.TrimSpace(closing))
+			if len(stack) == 0 || stack[len(stack)-1].name != name {
+				return Formatted{}, fmt.Errorf("unexpected closing tag </%s>", name)
+			}
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if top.emit && offset > top.entity.Offset {
+				top.entity.Length = offset - top.entity.Offset
+				entities = append(entities, top.entity)
+			}
+			continue
+		}
+
+		name, rest, _ := strings.Cut(tag, " ")
+		name = strings.ToLower(name)
+		attrs := map[string]string{}
+		for _, m := range htmlAttrRegex.FindAllStringSubmatch(rest, -1) {
+			attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2] + m[3] + m[4])
+		}
+
+		open := openTag{name: name, emit: true, entity: types.MessageEntity{Offset: offset}}
+		switch name {
+		case "b", "strong":
+			open.entity.Type = entityBold
+		case "i", "em":
+			open.entity.Type = entityItalic
+		case "u", "ins":
+			open.entity.Type = entityUnderline
+		case "s", "strike", "del":
+			open.entity.Type = entityStrikethrough
+		case "tg-spoiler":
+			open.entity.Type = entitySpoiler
+		case "span":
+			if attrs["class"] != "tg-spoiler" {
+				return Formatted{}, fmt.Errorf("unsupported span class %q", attrs["class"])
+			}
+			open.entity.Type = entitySpoiler
+		case "code":
+			open.entity.Type = entityCode
+			// <pre><code class="language-x"> sets the language of pre
+			if n := len(stack); n > 0 && stack[n-1].name == "pre" && stack[n-1].entity.Offset == offset {
+				stack[n-1].entity.Language = strings.TrimPrefix(attrs["class"], "language-")
+				open.emit = false
+			}
+		case "pre":
+			open.entity.Type = entityPre
+		case "a":
+			href := attrs["href"]
+			if id, ok := strings.CutPrefix(href, "tg://user?id="); ok {
+				userId, err := strconv.Atoi(id)
+				if err != nil {
+					return Formatted{}, fmt.Errorf("invalid user id in %q", href)
+				}
+				open.entity.Type = entityTextMention
+				open.entity.User = &types.User{Id: userId}
+			} else {
+				open.entity.Type = entityTextLink
+				open.entity.Url = href
+			}
+		case "tg-emoji":
+			open.entity.Type = entityCustomEmoji
+			open.entity.CustomEmojiId = attrs["emoji-id"]
+		case "blockquote":
+			open.entity.Type = entityBlockquote
+			if _, ok := attrs["expandable"]; ok {
+				open.entity.Type = entityExpandableBlockquote
+			}
+		default:
+			return Formatted{}, fmt.Errorf("unsupported tag <%s>", name)
+		}
+		stack = append(stack, open)
+	}
+
+	if len(stack) > 0 {
+		return Formatted{}, fmt.Errorf("unclosed tag <%s>", stack[len(stack)-1].name)
+	}
+	sortClosed(entities)
+	return Formatted{Text: sb.String(), Entities: entities}, nil
+}
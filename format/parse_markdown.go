@@ -0,0 +1,268 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+type mdMarker struct {
+	marker string
+	entity types.MessageEntity
+}
+
+type mdParser struct {
+	src      []rune
+	pos      int
+	sb       strings.Builder
+	offset   int
+	stack    []mdMarker
+	entities []types.MessageEntity
+}
+
+// ParseMarkdownV2 parses text formatted with the MarkdownV2 parse mode
+// into plain text and entities.
+func ParseMarkdownV2(s string) (Formatted, error) {
+	p := &mdParser{src: []rune(s)}
+	if err := p.parse(); err != nil {
+		return Formatted{}, err
+	}
+	sortClosed(p.entities)
+	return Formatted{Text: p.sb.String(), Entities: p.entities}, nil
+}
+
+func (p *mdParser) peek(s string) bool {
+	return strings.HasPrefix(string(p.src[p.pos:min(len(p.src), p.pos+len(s))]), s)
+}
+
+func (p *mdParser) write(r rune) {
+	p.sb.WriteRune(r)
+	p.offset += utf16.RuneLen(r)
+}
+
+func (p *mdParser) find(marker string) int {
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		if p.stack[i].marker == marker {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *mdParser) open(marker string, entity types.MessageEntity) {
+	entity.Offset = p.offset
+	p.stack = append(p.stack, mdMarker{marker: marker, entity: entity})
+}
+
+func (p *mdParser) close(idx int) types.MessageEntity {
+	e := p.stack[idx].entity
+	p.stack = append(p.stack[:idx], p.stack[idx+1:]...)
+	e.Length = p.offset - e.Offset
+	if e.Length > 0 {
+		p.entities = append(p.entities, e)
+	}
+	return e
+}
+
+func (p *mdParser) toggle(marker, entityType string) {
+	if idx := p.find(marker); idx != -1 {
+		p.close(idx)
+		return
+	}
+	p.open(marker, types.MessageEntity{Type: entityType})
+}
+
+func (p *mdParser) blockquoteOpen() bool {
+	return p.find(">") != -1 || p.find("**>") != -1
+}
+
+func (p *mdParser) parse() error {
+	lineStart := true
+	for p.pos < len(p.src) {
+		if lineStart && !p.blockquoteOpen() {
+			if p.peek("**>") {
+				p.open("**>", types.MessageEntity{Type: entityExpandableBlockquote})
+				p.pos += 3
+			} else if p.peek(">") {
+				p.open(">", types.MessageEntity{Type: entityBlockquote})
+				p.pos++
+			}
+		}
+		lineStart = false
+
+		r := p.src[p.pos]
+		switch {
+		case r == '\\':
+			if p.pos+1 >= len(p.src) {
+				return fmt.Errorf("trailing backslash")
+			}
+			p.write(p.src[p.pos+1])
+			p.pos += 2
+		case r == '\r':
+			// separates ambiguous markers, e.g. "_\r__"
+			p.pos++
+		case p.peek("```"):
+			if err := p.parsePre(); err != nil {
+				return err
+			}
+		case r == '`':
+			p.pos++
+			if err := p.parseCode(); err != nil {
+				return err
+			}
+		case r == '*':
+			p.toggle("*", entityBold)
+			p.pos++
+		case p.peek("__"):
+			p.toggle("__", entityUnderline)
+			p.pos += 2
+		case r == '_':
+			p.toggle("_", entityItalic)
+			p.pos++
+		case r == '~':
+			p.toggle("~", entityStrikethrough)
+			p.pos++
+		case p.peek("||"):
+			end := p.pos+2 == len(p.src) || p.src[p.pos+2] == '\n'
+			if idx := p.find("**>"); idx != -1 && end && p.find("||") == -1 {
+				p.close(idx)
+			} else {
+				p.toggle("||", entitySpoiler)
+			}
+			p.pos += 2
+		case p.peek("!["):
+			p.open("![", types.MessageEntity{Type: entityCustomEmoji})
+			p.pos += 2
+		case r == '[':
+			p.open("[", types.MessageEntity{Type: entityTextLink})
+			p.pos++
+		case r == ']':
+			if err := p.parseLinkEnd(); err != nil {
+				return err
+			}
+		case r == '\n':
+			p.pos++
+			if idx := max(p.find(">"), p.find("**>")); idx != -1 {
+				if p.peek(">") {
+					p.write('\n')
+					p.pos++
+					continue
+				}
+				if p.stack[idx].marker == ">" {
+					p.close(idx)
+				}
+			}
+			p.write('\n')
+			lineStart = true
+		default:
+			p.write(r)
+			p.pos++
+		}
+	}
+
+	if idx := p.find(">"); idx != -1 {
+		p.close(idx)
+	}
+	if len(p.stack) > 0 {
+		return fmt.Errorf("unclosed %q", p.stack[len(p.stack)-1].marker)
+	}
+	return nil
+}
+
+// literal reads until the unescaped terminator, only "\" escapes are
+// processed
+func (p *mdParser) literal(terminator string) (string, error) {
+	sb := strings.Builder{}
+	for p.pos < len(p.src) {
+		if p.peek(terminator) {
+			p.pos += len(terminator)
+			return sb.String(), nil
+		}
+		r := p.src[p.pos]
+		if r == '\\' && p.pos+1 < len(p.src) {
+			r = p.src[p.pos+1]
+			p.pos++
+		}
+		sb.WriteRune(r)
+		p.pos++
+	}
+	return "", fmt.Errorf("missing closing %q", terminator)
+}
+
+func (p *mdParser) writeString(s string) {
+	for _, r := range s {
+		p.write(r)
+	}
+}
+
+func (p *mdParser) parseCode() error {
+	text, err := p.literal("`")
+	if err != nil {
+		return err
+	}
+	p.open("`", types.MessageEntity{Type: entityCode})
+	p.writeString(text)
+	p.close(len(p.stack) - 1)
+	return nil
+}
+
+func (p *mdParser) parsePre() error {
+	p.pos += 3
+	language := ""
+	if nl := strings.IndexRune(string(p.src[p.pos:]), '\n'); nl != -1 {
+		header := []rune(string(p.src[p.pos:])[:nl])
+		if !strings.ContainsAny(string(header), "` ") {
+			language = string(header)
+			p.pos += len(header) + 1
+		}
+	}
+	text, err := p.literal("```")
+	if err != nil {
+		return err
+	}
+	text = strings.TrimSuffix(text, "\n")
+	p.open("```", types.MessageEntity{Type: entityPre, Language: language})
+	p.writeString(text)
+	p.close(len(p.stack) - 1)
+	return nil
+}
+
+func (p *mdParser) parseLinkEnd() error {
+	idx := max(p.find("["), p.find("!["))
+	if idx == -1 {
+		return fmt.Errorf("unexpected ']'")
+	}
+	p.pos++
+	if !p.peek("(") {
+		return fmt.Errorf("missing url after ']'")
+	}
+	p.pos++
+	url, err := p.literal(")")
+	if err != nil {
+		return err
+	}
+
+	m := &p.stack[idx]
+	switch {
+	case m.marker == "![":
+		id, ok := strings.CutPrefix(url, "tg://emoji?id=")
+		if !ok {
+			return fmt.Errorf("invalid custom emoji url %q", url)
+		}
+		m.entity.CustomEmojiId = id
+	case strings.HasPrefix(url, "tg://user?id="):
+		userId, err := strconv.Atoi(strings.TrimPrefix(url, "tg://user?id="))
+		if err != nil {
+			return fmt.Errorf("invalid user id in %q", url)
+		}
+		m.entity.Type = entityTextMention
+		m.entity.User = &types.User{Id: userId}
+	default:
+		m.entity.Url = url
+	}
+	p.close(idx)
+	return nil
+}
@@ -0,0 +1,92 @@
+package format
+
+import (
+	"slices"
+	"unicode/utf16"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+// FromMessage returns the text (or caption) of a message with its
+// entities
+func FromMessage(m *types.Message) Formatted {
+	if m.Text == "" && m.Caption != "" {
+		return Formatted{Text: m.Caption, Entities: m.CaptionEntities}
+	}
+	return Formatted{Text: m.Text, Entities: m.Entities}
+}
+
+func (f Formatted) HTML() string {
+	return HTML(f.Nodes()...)
+}
+
+func (f Formatted) MarkdownV2() string {
+	return MarkdownV2(f.Nodes()...)
+}
+
+// Nodes converts text and entities to a tree of nodes. Entities that
+// overlap without being nested are split, so the result is a valid
+// tree that covers exactly the same ranges.
+func (f Formatted) Nodes() []Node {
+	units := utf16.Encode([]rune(f.Text))
+
+	// outer entities first, so they can contain the inner ones
+	entities := slices.Clone(f.Entities)
+	SortEntities(entities)
+
+	bounds := []int{0, len(units)}
+	for _, e := range entities {
+		start := max(0, min(e.Offset, len(units)))
+		end := max(start, min(e.Offset+e.Length, len(units)))
+		bounds = append(bounds, start, end)
+	}
+	slices.Sort(bounds)
+	bounds = slices.Compact(bounds)
+
+	root := &Node{}
+	// stack[i] is the node of the entity open[i]; stack[0] is the root
+	stack := []*Node{root}
+	open := []int{}
+	for i := 0; i+1 < len(bounds); i++ {
+		start, end := bounds[i], bounds[i+1]
+
+		active := []int{}
+		for idx, e := range entities {
+			if e.Offset <= start && end <= e.Offset+e.Length {
+				active = append(active, idx)
+			}
+		}
+
+		// keep the common prefix open, close the rest
+		common := 0
+		for common < len(open) && common < len(active) && open[common] == active[common] {
+			common++
+		}
+		for len(open) > common {
+			closeNode(&stack)
+			open = open[:len(open)-1]
+		}
+		for _, idx := range active[common:] {
+			child := &Node{entity: entities[idx]}
+			child.entity.Offset, child.entity.Length = 0, 0
+			stack = append(stack, child)
+			open = append(open, idx)
+		}
+
+		top := stack[len(stack)-1]
+		top.children = append(top.children, Text(string(utf16.Decode(units[start:end]))))
+	}
+	for len(stack) > 1 {
+		closeNode(&stack)
+	}
+	return root.children
+}
+
+// closeNode pops the top node and appends it to its parent
+func closeNode(stack *[]*Node) {
+	s := *stack
+	n := s[len(s)-1]
+	parent := s[len(s)-2]
+	parent.children = append(parent.children, *n)
+	*stack = s[:len(s)-1]
+}
@@ -0,0 +1,168 @@
+package format
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+type parser struct {
+	name   string
+	render func(Formatted) string
+	parse  func(string) (Formatted, error)
+}
+
+var parsers = []parser{
+	{"HTML", Formatted.HTML, ParseHTML},
+	{"MarkdownV2", Formatted.MarkdownV2, ParseMarkdownV2},
+}
+
+func roundTrip(t *testing.T, f Formatted) {
+	t.Helper()
+	for _, p := range parsers {
+		rendered := p.render(f)
+		got, err := p.parse(rendered)
+		if err != nil {
+			t.Errorf("%s: parsing %q: %v", p.name, rendered, err)
+			continue
+		}
+		if got.Text != f.Text {
+			t.Errorf("%s: text %q, want %q (rendered %q)", p.name, got.Text, f.Text, rendered)
+		}
+		if (len(got.Entities) > 0 || len(f.Entities) > 0) && !reflect.DeepEqual(got.Entities, f.Entities) {
+			t.Errorf("%s: rendered %q\n got entities %+v\nwant entities %+v", p.name, rendered, got.Entities, f.Entities)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Formatted
+	}{
+		{"plain with special characters", Build(Text("1 < 2 & *not bold* _x_ [a](b) > q"))},
+		{"nested", Build(Bold(Text("bold "), Italic(Text("both"), Strikethrough(Text("all")))), Text(" end"))},
+		{"same range", Build(Italic(Underline(Text("iu"))), Text(" "), Bold(Spoiler(Text("bs"))))},
+		{"italic ending with underline", Build(Italic(Text("a"), Underline(Text("b"))), Text("c"))},
+		{"pre with language", Build(Text("code:\n"), Pre("go", "x := `a\\b` <tag>"), Text("\nafter"))},
+		{"pre without language", Build(Pre("", "plain pre"))},
+		{"inline code", Build(Text("run "), Code("go test ./..."))},
+		{"link with escaped characters", Build(Link("https://example.com/a_(b)?q=1&r=\\", Text("link")))},
+		{"mention", Build(Text("hi "), Mention(types.User{Id: 42}, Text("you")))},
+		{"custom emoji", Build(CustomEmoji("5368324170671202286", "👍"), Text(" ok"))},
+		{"blockquote", Build(Text("before\n"), Blockquote(Text("line 1\nline 2")), Text("\nafter"))},
+		{"expandable blockquote", Build(Text("before\n"), ExpandableBlockquote(Text("line 1\nline 2")))},
+		{"emoji and cyrillic offsets", Build(Text("😀 👍🏽 "), Bold(Text("жирный")), Text(" 𝄞 "), Italic(Text("курсив")))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roundTrip(t, tt.f)
+		})
+	}
+}
+
+func TestItalicUnderlineAmbiguity(t *testing.T) {
+	f := Build(Italic(Underline(Text("x"))))
+	if got := f.MarkdownV2(); got != "___x___\r" {
+		t.Errorf("MarkdownV2() = %q, want %q", got, "___x___\r")
+	}
+
+	got, err := ParseMarkdownV2("_\r__x__\r_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.MessageEntity{
+		{Type: entityItalic, Offset: 0, Length: 1},
+		{Type: entityUnderline, Offset: 0, Length: 1},
+	}
+	if got.Text != "x" || !reflect.DeepEqual(got.Entities, want) {
+		t.Errorf("ParseMarkdownV2 = %+v", got)
+	}
+}
+
+func TestUTF16Offsets(t *testing.T) {
+	for _, p := range parsers {
+		f, err := p.parse(p.render(Build(Text("😀 "), Bold(Text("жир")))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []types.MessageEntity{{Type: entityBold, Offset: 3, Length: 3}}
+		if !reflect.DeepEqual(f.Entities, want) {
+			t.Errorf("%s: entities %+v, want %+v", p.name, f.Entities, want)
+		}
+	}
+}
+
+// coverage returns the sorted entity types covering each UTF-16 unit
+func coverage(f Formatted) []string {
+	result := make([]string, types.UTF16Len(f.Text))
+	for i := range result {
+		kinds := []string{}
+		for _, e := range f.Entities {
+			if e.Offset <= i && i < e.Offset+e.Length {
+				kinds = append(kinds, e.Type)
+			}
+		}
+		slices.Sort(kinds)
+		result[i] = strings.Join(kinds, ",")
+	}
+	return result
+}
+
+func TestOverlappingEntities(t *testing.T) {
+	f := Formatted{
+		Text: "abcdefgh",
+		Entities: []types.MessageEntity{
+			{Type: entityBold, Offset: 0, Length: 5},
+			{Type: entityItalic, Offset: 3, Length: 5},
+			{Type: entityTextLink, Offset: 2, Length: 4, Url: "https://example.com"},
+		},
+	}
+	for _, p := range parsers {
+		rendered := p.render(f)
+		got, err := p.parse(rendered)
+		if err != nil {
+			t.Fatalf("%s: parsing %q: %v", p.name, rendered, err)
+		}
+		if got.Text != f.Text || !slices.Equal(coverage(got), coverage(f)) {
+			t.Errorf("%s: rendered %q, got %+v", p.name, rendered, got)
+		}
+	}
+}
+
+func TestMentionWithoutUser(t *testing.T) {
+	f := Formatted{
+		Text:     "someone",
+		Entities: []types.MessageEntity{{Type: entityTextMention, Offset: 0, Length: 7}},
+	}
+	if got := f.HTML(); got != "someone" {
+		t.Errorf("HTML() = %q", got)
+	}
+	if got := f.MarkdownV2(); got != "someone" {
+		t.Errorf("MarkdownV2() = %q", got)
+	}
+}
+
+func TestFromMessage(t *testing.T) {
+	entities := []types.MessageEntity{{Type: entityBold, Offset: 0, Length: 4}}
+	m := &types.Message{Caption: "bold caption", CaptionEntities: entities}
+	if got := FromMessage(m).HTML(); got != "<b>bold</b> caption" {
+		t.Errorf("HTML() = %q", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{"<b>unclosed", "<b><i>x</b></i>", "<unknown>x</unknown>", "<a href='x'>x"} {
+		if _, err := ParseHTML(s); err == nil {
+			t.Errorf("ParseHTML(%q) succeeded", s)
+		}
+	}
+	for _, s := range []string{"*unclosed", "`code", "```\npre", "[text](url", "trailing \\", "text]"} {
+		if _, err := ParseMarkdownV2(s); err == nil {
+			t.Errorf("ParseMarkdownV2(%q) succeeded", s)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package format
+
+import (
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+// Split breaks f into parts of at most limit UTF-16 code units. Parts are
+// cut at paragraph, line or word boundaries when possible and entities
+// are clipped and re-based to the part they fall in. Whitespace around
+// the cuts is dropped, so no part is empty or only whitespace.
+func (f Formatted) Split(limit int) []Formatted {
+	runes := []rune(f.Text)
+	if limit <= 0 || types.UTF16Len(f.Text) <= limit {
+		return []Formatted{f}
+	}
+
+	// offsets[i] is the UTF-16 offset of runes[i]
+	offsets := make([]int, len(runes)+1)
+	for i, r := range runes {
+		offsets[i+1] = offsets[i] + utf16.RuneLen(r)
+	}
+
+	parts := []Formatted{}
+	start := 0
+	for {
+		for start < len(runes) && unicode.IsSpace(runes[start]) {
+			start++
+		}
+		if start == len(runes) {
+			break
+		}
+
+		end, next := len(runes), len(runes)
+		if offsets[end]-offsets[start] > limit {
+			end = start
+			for end < len(runes) && offsets[end+1]-offsets[start] <= limit {
+				end++
+			}
+			end = max(end, start+1)
+			next = end
+			// a separator right after the longest fitting prefix is a
+			// valid cut point as well
+			if cut := splitPoint(runes[start:min(end+1, len(runes))]); cut > 0 {
+				end, next = start+cut, start+cut
+			}
+		}
+		for end > start && unicode.IsSpace(runes[end-1]) {
+			end--
+		}
+		parts = append(parts, f.slice(runes[start:end], offsets[start], offsets[end]))
+		start = next
+	}
+	return parts
+}
+
+// splitPoint returns the index of the last paragraph, line or word
+// separator in runes
+func splitPoint(runes []rune) int {
+	for i := len(runes) - 2; i > 0; i-- {
+		if runes[i] == '\n' && runes[i+1] == '\n' {
+			return i
+		}
+	}
+	for i := len(runes) - 1; i > 0; i-- {
+		if runes[i] == '\n' {
+			return i
+		}
+	}
+	for i := len(runes) - 1; i > 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			return i
+		}
+	}
+	return 0
+}
+
+func (f Formatted) slice(runes []rune, from, to int) Formatted {
+	part := Formatted{Text: string(runes)}
+	for _, e := range f.Entities {
+		start, end := max(e.Offset, from), min(e.Offset+e.Length, to)
+		if end <= start {
+			continue
+		}
+		e.Offset, e.Length = start-from, end-start
+		part.Entities = append(part.Entities, e)
+	}
+	return part
+}
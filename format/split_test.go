@@ -0,0 +1,88 @@
+package format
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+func TestSplitText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{"fits", "short text", 10, []string{"short text"}},
+		{"paragraph before line", "aaa\nbb\n\ncc\ndd", 12, []string{"aaa\nbb", "cc\ndd"}},
+		{"line before word", "aa bb\ncc dd ee", 10, []string{"aa bb", "cc dd ee"}},
+		{"word", "one two three", 8, []string{"one two", "three"}},
+		{"separator right after the limit", "aaaa bbbb", 4, []string{"aaaa", "bbbb"}},
+		{"hard cut", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"whitespace run", "a" + strings.Repeat(" ", 20) + "b", 10, []string{"a", "b"}},
+		{"blank lines", "a\n\n\n\n\n\n\n\n\n\n\nb", 4, []string{"a", "b"}},
+		{"leading and trailing whitespace", "   abc def   ", 5, []string{"abc", "def"}},
+		{"only whitespace", strings.Repeat(" ", 12), 5, []string{}},
+		{"surrogate pair at the limit", "ab😀cd", 3, []string{"ab", "😀c", "d"}},
+		{"surrogate pair longer than the limit", "😀😀", 1, []string{"😀", "😀"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := Formatted{Text: tt.text}.Split(tt.limit)
+			got := []string{}
+			for _, p := range parts {
+				got = append(got, p.Text)
+				if tt.limit >= 2 && types.UTF16Len(p.Text) > tt.limit {
+					t.Errorf("part %q is longer than %d", p.Text, tt.limit)
+				}
+				if strings.TrimFunc(p.Text, unicode.IsSpace) == "" && p.Text != tt.text {
+					t.Errorf("part %q is only whitespace", p.Text)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split(%d) = %q, want %q", tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitEntities(t *testing.T) {
+	f := Build(
+		Text("😀 "), Bold(Text("bold text")), Text(" plain\n\n"),
+		Italic(Text("italic")), Text(strings.Repeat(" ", 10)), Code("x"),
+	)
+	parts := f.Split(12)
+
+	want := []Formatted{
+		{Text: "😀 bold text", Entities: []types.MessageEntity{{Type: entityBold, Offset: 3, Length: 9}}},
+		{Text: "plain"},
+		{Text: "italic", Entities: []types.MessageEntity{{Type: entityItalic, Offset: 0, Length: 6}}},
+		{Text: "x", Entities: []types.MessageEntity{{Type: entityCode, Offset: 0, Length: 1}}},
+	}
+	if len(parts) != len(want) {
+		t.Fatalf("Split = %+v, want %+v", parts, want)
+	}
+	for i := range want {
+		if parts[i].Text != want[i].Text || (len(want[i].Entities) > 0 || len(parts[i].Entities) > 0) &&
+			!reflect.DeepEqual(parts[i].Entities, want[i].Entities) {
+			t.Errorf("part %d = %+v, want %+v", i, parts[i], want[i])
+		}
+	}
+}
+
+func TestSplitClipsEntities(t *testing.T) {
+	f := Build(Bold(Text("aaaa bbbb cccc")))
+	parts := f.Split(5)
+	if len(parts) != 3 {
+		t.Fatalf("Split = %+v, want 3 parts", parts)
+	}
+	for _, p := range parts {
+		want := []types.MessageEntity{{Type: entityBold, Offset: 0, Length: 4}}
+		if !reflect.DeepEqual(p.Entities, want) {
+			t.Errorf("part %q entities %+v, want %+v", p.Text, p.Entities, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package telbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thehxdev/telbot/format"
+	"github.com/thehxdev/telbot/types"
+)
+
+// SendLongMessage sends a text that may be longer than MaxMessageLength.
+// The text is split on paragraph, line or word boundaries and each part is
+// sent as a reply to the previous one. Text in HTML or MarkdownV2 parse
+// mode is converted to entities first so formatting survives the split.
+// ReplyMarkup is attached to the last part only. Messages sent before an
+// error are returned with it.
+func (b *Bot) SendLongMessage(ctx context.Context, params TextMessageParams) ([]*types.Message, error) {
+	if types.UTF16Len(params.Text) <= MaxMessageLength {
+		msg, err := b.SendMessage(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Message{msg}, nil
+	}
+
+	text := format.Formatted{Text: params.Text, Entities: params.Entities}
+	var err error
+	switch params.ParseMode {
+	case "":
+	case ParseModeHTML:
+		text, err = format.ParseHTML(params.Text)
+	case ParseModeMarkdownV2:
+		text, err = format.ParseMarkdownV2(params.Text)
+	default:
+		err = fmt.Errorf("parse mode %q is not supported for long messages", params.ParseMode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	parts := text.Split(MaxMessageLength)
+	if len(parts) == 0 {
+		return nil, errors.New("message text is empty")
+	}
+	replyMarkup := params.ReplyMarkup
+	messages := make([]*types.Message, 0, len(parts))
+	for i, part := range parts {
+		params.Text, params.Entities, params.ParseMode = part.Text, part.Entities, ""
+		params.ReplyMarkup = nil
+		if i == len(parts)-1 {
+			params.ReplyMarkup = replyMarkup
+		}
+
+		msg, err := b.SendMessage(ctx, params)
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, msg)
+
+		params.ReplyParameters = &ReplyParameters{MessageId: msg.Id}
+		params.ReplyToMessageId = 0
+		params.MessageEffectId = ""
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,68 @@
+package telbot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+func TestSendLongMessage(t *testing.T) {
+	type sentMessage struct {
+		Text            string
+		ParseMode       string                `json:"parse_mode"`
+		Entities        []types.MessageEntity `json:"entities"`
+		ReplyParameters *ReplyParameters      `json:"reply_parameters"`
+		ReplyMarkup     json.RawMessage       `json:"reply_markup"`
+	}
+	sent := []sentMessage{}
+	bot, _ := newTestBot(t, map[string]http.HandlerFunc{
+		MethodSendMessage: func(w http.ResponseWriter, r *http.Request) {
+			params := sentMessage{}
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+				t.Error(err)
+			}
+			if strings.TrimSpace(params.Text) == "" {
+				writeError(w, APIResponse{ErrorCode: 400, Description: "Bad Request: message text is empty"})
+				return
+			}
+			sent = append(sent, params)
+			writeResult(w, types.Message{Id: len(sent), Text: params.Text})
+		},
+	})
+
+	paragraph := strings.Repeat("word ", 500)
+	text := "<b>" + paragraph + "</b>" + strings.Repeat(" ", MaxMessageLength) + "\n\n" + paragraph
+	msgs, err := bot.SendLongMessage(context.Background(), TextMessageParams{
+		ChatId:      1,
+		Text:        text,
+		ParseMode:   ParseModeHTML,
+		ReplyMarkup: &types.ForceReply{Selective: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 || len(sent) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(sent))
+	}
+
+	first, second := sent[0], sent[1]
+	if first.Text != strings.TrimSpace(paragraph) || second.Text != first.Text {
+		t.Errorf("unexpected parts %q and %q", first.Text, second.Text)
+	}
+	if first.ParseMode != "" || len(first.Entities) != 1 || first.Entities[0].Type != "bold" {
+		t.Errorf("first part: parse mode %q, entities %+v", first.ParseMode, first.Entities)
+	}
+	if len(second.Entities) != 0 {
+		t.Errorf("second part has entities %+v", second.Entities)
+	}
+	if first.ReplyMarkup != nil || second.ReplyMarkup == nil {
+		t.Error("reply markup must be attached to the last part only")
+	}
+	if second.ReplyParameters == nil || second.ReplyParameters.MessageId != msgs[0].Id {
+		t.Errorf("second part doesn't reply to the first: %+v", second.ReplyParameters)
+	}
+}
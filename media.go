@@ -0,0 +1,241 @@
+package telbot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+type PhotoParams struct {
+	BusinessConnectionId  string                `json:"business_connection_id,omitempty"`
+	ChatId                int                   `json:"chat_id"`
+	MessageThreadId       int                   `json:"message_thread_id,omitempty"`
+	Photo                 IFileInfo             `json:"-"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []types.MessageEntity `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	HasSpoiler            bool                  `json:"has_spoiler,omitempty"`
+	DisableNotification   bool                  `json:"disable_notification,omitempty"`
+	ProtectContent        bool                  `json:"protect_content,omitempty"`
+	AllowPaidBroadcast    bool                  `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectId       string                `json:"message_effect_id,omitempty"`
+	ReplyParameters       *ReplyParameters      `json:"reply_parameters,omitempty"`
+	ReplyMarkup           IReplyMarkup          `json:"reply_markup,omitempty"`
+}
+
+type AudioParams struct {
+	BusinessConnectionId string                `json:"business_connection_id,omitempty"`
+	ChatId               int                   `json:"chat_id"`
+	MessageThreadId      int                   `json:"message_thread_id,omitempty"`
+	Audio                IFileInfo             `json:"-"`
+	Caption              string                `json:"caption,omitempty"`
+	ParseMode            string                `json:"parse_mode,omitempty"`
+	CaptionEntities      []types.MessageEntity `json:"caption_entities,omitempty"`
+	Duration             int                   `json:"duration,omitempty"`
+	Performer            string                `json:"performer,omitempty"`
+	Title                string                `json:"title,omitempty"`
+	Thumbnail            IFileInfo             `json:"-"`
+	DisableNotification  bool                  `json:"disable_notification,omitempty"`
+	ProtectContent       bool                  `json:"protect_content,omitempty"`
+	AllowPaidBroadcast   bool                  `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectId      string                `json:"message_effect_id,omitempty"`
+	ReplyParameters      *ReplyParameters      `json:"reply_parameters,omitempty"`
+	ReplyMarkup          IReplyMarkup          `json:"reply_markup,omitempty"`
+}
+
+type DocumentParams struct {
+	BusinessConnectionId        string                `json:"business_connection_id,omitempty"`
+	ChatId                      int                   `json:"chat_id"`
+	MessageThreadId             int                   `json:"message_thread_id,omitempty"`
+	Document                    IFileInfo             `json:"-"`
+	Thumbnail                   IFileInfo             `json:"-"`
+	Caption                     string                `json:"caption,omitempty"`
+	ParseMode                   string                `json:"parse_mode,omitempty"`
+	CaptionEntities             []types.MessageEntity `json:"caption_entities,omitempty"`
+	DisableContentTypeDetection bool                  `json:"disable_content_type_detection,omitempty"`
+	DisableNotification         bool                  `json:"disable_notification,omitempty"`
+	ProtectContent              bool                  `json:"protect_content,omitempty"`
+	AllowPaidBroadcast          bool                  `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectId             string                `json:"message_effect_id,omitempty"`
+	ReplyParameters             *ReplyParameters      `json:"reply_parameters,omitempty"`
+	ReplyMarkup                 IReplyMarkup          `json:"reply_markup,omitempty"`
+}
+
+type VideoParams struct {
+	BusinessConnectionId  string                `json:"business_connection_id,omitempty"`
+	ChatId                int                   `json:"chat_id"`
+	MessageThreadId       int                   `json:"message_thread_id,omitempty"`
+	Video                 IFileInfo             `json:"-"`
+	Duration              int                   `json:"duration,omitempty"`
+	Width                 int                   `json:"width,omitempty"`
+	Height                int                   `json:"height,omitempty"`
+	Thumbnail             IFileInfo             `json:"-"`
+	Cover                 IFileInfo             `json:"-"`
+	StartTimestamp        int                   `json:"start_timestamp,omitempty"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []types.MessageEntity `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	HasSpoiler            bool                  `json:"has_spoiler,omitempty"`
+	SupportsStreaming     bool                  `json:"supports_streaming,omitempty"`
+	DisableNotification   bool                  `json:"disable_notification,omitempty"`
+	ProtectContent        bool                  `json:"protect_content,omitempty"`
+	AllowPaidBroadcast    bool                  `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectId       string                `json:"message_effect_id,omitempty"`
+	ReplyParameters       *ReplyParameters      `json:"reply_parameters,omitempty"`
+	ReplyMarkup           IReplyMarkup          `json:"reply_markup,omitempty"`
+}
+
+type AnimationParams struct {
+	BusinessConnectionId  string                `json:"business_connection_id,omitempty"`
+	ChatId                int                   `json:"chat_id"`
+	MessageThreadId       int                   `json:"message_thread_id,omitempty"`
+	Animation             IFileInfo             `json:"-"`
+	Duration              int                   `json:"duration,omitempty"`
+	Width                 int                   `json:"width,omitempty"`
+	Height                int                   `json:"height,omitempty"`
+	Thumbnail             IFileInfo             `json:"-"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []types.MessageEntity `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	HasSpoiler            bool                  `json:"has_spoiler,omitempty"`
+	DisableNotification   bool                  `json:"disable_notification,omitempty"`
+	ProtectContent        bool                  `json:"protect_content,omitempty"`
+	AllowPaidBroadcast    bool                  `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectId       string                `json:"message_effect_id,omitempty"`
+	ReplyParameters       *ReplyParameters      `json:"reply_parameters,omitempty"`
+	ReplyMarkup           IReplyMarkup          `json:"reply_markup,omitempty"`
+}
+
+type VoiceParams struct {
+	BusinessConnectionId string                `json:"business_connection_id,omitempty"`
+	ChatId               int                   `json:"chat_id"`
+	MessageThreadId      int                   `json:"message_thread_id,omitempty"`
+	Voice                IFileInfo             `json:"-"`
+	Caption              string                `json:"caption,omitempty"`
+	ParseMode            string                `json:"parse_mode,omitempty"`
+	CaptionEntities      []types.MessageEntity `json:"caption_entities,omitempty"`
+	Duration             int                   `json:"duration,omitempty"`
+	DisableNotification  bool                  `json:"disable_notification,omitempty"`
+	ProtectContent       bool                  `json:"protect_content,omitempty"`
+	AllowPaidBroadcast   bool                  `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectId      string                `json:"message_effect_id,omitempty"`
+	ReplyParameters      *ReplyParameters      `json:"reply_parameters,omitempty"`
+	ReplyMarkup          IReplyMarkup          `json:"reply_markup,omitempty"`
+}
+
+type VideoNoteParams struct {
+	BusinessConnectionId string           `json:"business_connection_id,omitempty"`
+	ChatId               int              `json:"chat_id"`
+	MessageThreadId      int              `json:"message_thread_id,omitempty"`
+	VideoNote            IFileInfo        `json:"-"`
+	Duration             int              `json:"duration,omitempty"`
+	Length               int              `json:"length,omitempty"`
+	Thumbnail            IFileInfo        `json:"-"`
+	DisableNotification  bool             `json:"disable_notification,omitempty"`
+	ProtectContent       bool             `json:"protect_content,omitempty"`
+	AllowPaidBroadcast   bool             `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectId      string           `json:"message_effect_id,omitempty"`
+	ReplyParameters      *ReplyParameters `json:"reply_parameters,omitempty"`
+	ReplyMarkup          IReplyMarkup     `json:"reply_markup,omitempty"`
+}
+
+func (p *PhotoParams) UploadMethod() string     { return MethodSendPhoto }
+func (p *AudioParams) UploadMethod() string     { return MethodSendAudio }
+func (p *DocumentParams) UploadMethod() string  { return MethodSendDocument }
+func (p *VideoParams) UploadMethod() string     { return MethodSendVideo }
+func (p *AnimationParams) UploadMethod() string { return MethodSendAnimation }
+func (p *VoiceParams) UploadMethod() string     { return MethodSendVoice }
+func (p *VideoNoteParams) UploadMethod() string { return MethodSendVideoNote }
+
+func (p *PhotoParams) ToStringMap() (map[string]string, error)     { return ParamsToStringMap(p) }
+func (p *AudioParams) ToStringMap() (map[string]string, error)     { return ParamsToStringMap(p) }
+func (p *DocumentParams) ToStringMap() (map[string]string, error)  { return ParamsToStringMap(p) }
+func (p *VideoParams) ToStringMap() (map[string]string, error)     { return ParamsToStringMap(p) }
+func (p *AnimationParams) ToStringMap() (map[string]string, error) { return ParamsToStringMap(p) }
+func (p *VoiceParams) ToStringMap() (map[string]string, error)     { return ParamsToStringMap(p) }
+func (p *VideoNoteParams) ToStringMap() (map[string]string, error) { return ParamsToStringMap(p) }
+
+// collects the non-nil files and names them after their fields
+func mediaFiles(main IFileInfo, mainKind string, optional map[string]IFileInfo) ([]IFileInfo, error) {
+	if isNilFile(main) {
+		return nil, errors.New("no " + mainKind + " provided to send")
+	}
+	files := []IFileInfo{withKind(main, mainKind)}
+	for kind, file := range optional {
+		if !isNilFile(file) {
+			files = append(files, withKind(file, kind))
+		}
+	}
+	return files, nil
+}
+
+func (b *Bot) SendPhoto(ctx context.Context, params PhotoParams) (*types.Message, error) {
+	files, err := mediaFiles(params.Photo, "photo", nil)
+	if err != nil {
+		return nil, err
+	}
+	return b.UploadFile(ctx, &params, files)
+}
+
+func (b *Bot) SendAudio(ctx context.Context, params AudioParams) (*types.Message, error) {
+	files, err := mediaFiles(params.Audio, "audio", map[string]IFileInfo{
+		"thumbnail": params.Thumbnail,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.UploadFile(ctx, &params, files)
+}
+
+func (b *Bot) SendDocument(ctx context.Context, params DocumentParams) (*types.Message, error) {
+	files, err := mediaFiles(params.Document, "document", map[string]IFileInfo{
+		"thumbnail": params.Thumbnail,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.UploadFile(ctx, &params, files)
+}
+
+func (b *Bot) SendVideo(ctx context.Context, params VideoParams) (*types.Message, error) {
+	files, err := mediaFiles(params.Video, "video", map[string]IFileInfo{
+		"thumbnail": params.Thumbnail,
+		"cover":     params.Cover,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.UploadFile(ctx, &params, files)
+}
+
+func (b *Bot) SendAnimation(ctx context.Context, params AnimationParams) (*types.Message, error) {
+	files, err := mediaFiles(params.Animation, "animation", map[string]IFileInfo{
+		"thumbnail": params.Thumbnail,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.UploadFile(ctx, &params, files)
+}
+
+func (b *Bot) SendVoice(ctx context.Context, params VoiceParams) (*types.Message, error) {
+	files, err := mediaFiles(params.Voice, "voice", nil)
+	if err != nil {
+		return nil, err
+	}
+	return b.UploadFile(ctx, &params, files)
+}
+
+func (b *Bot) SendVideoNote(ctx context.Context, params VideoNoteParams) (*types.Message, error) {
+	files, err := mediaFiles(params.VideoNote, "video_note", map[string]IFileInfo{
+		"thumbnail": params.Thumbnail,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.UploadFile(ctx, &params, files)
+}
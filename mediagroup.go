@@ -0,0 +1,190 @@
+package telbot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+const (
+	minMediaGroupSize = 2
+	maxMediaGroupSize = 10
+)
+
+// IInputMedia is implemented by InputMediaPhoto, InputMediaVideo,
+// InputMediaAudio and InputMediaDocument.
+type IInputMedia interface {
+	inputMediaType() string
+	// files of the media by field name ("media", "thumbnail", ...)
+	inputMediaFiles() map[string]IFileInfo
+}
+
+type InputMediaPhoto struct {
+	Media                 IFileInfo             `json:"-"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []types.MessageEntity `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	HasSpoiler            bool                  `json:"has_spoiler,omitempty"`
+}
+
+type InputMediaVideo struct {
+	Media                 IFileInfo             `json:"-"`
+	Thumbnail             IFileInfo             `json:"-"`
+	Cover                 IFileInfo             `json:"-"`
+	StartTimestamp        int                   `json:"start_timestamp,omitempty"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []types.MessageEntity `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	Width                 int                   `json:"width,omitempty"`
+	Height                int                   `json:"height,omitempty"`
+	Duration              int                   `json:"duration,omitempty"`
+	SupportsStreaming     bool                  `json:"supports_streaming,omitempty"`
+	HasSpoiler            bool                  `json:"has_spoiler,omitempty"`
+}
+
+type InputMediaAudio struct {
+	Media           IFileInfo             `json:"-"`
+	Thumbnail       IFileInfo             `json:"-"`
+	Caption         string                `json:"caption,omitempty"`
+	ParseMode       string                `json:"parse_mode,omitempty"`
+	CaptionEntities []types.MessageEntity `json:"caption_entities,omitempty"`
+	Duration        int                   `json:"duration,omitempty"`
+	Performer       string                `json:"performer,omitempty"`
+	Title           string                `json:"title,omitempty"`
+}
+
+type InputMediaDocument struct {
+	Media                       IFileInfo             `json:"-"`
+	Thumbnail                   IFileInfo             `json:"-"`
+	Caption                     string                `json:"caption,omitempty"`
+	ParseMode                   string                `json:"parse_mode,omitempty"`
+	CaptionEntities             []types.MessageEntity `json:"caption_entities,omitempty"`
+	DisableContentTypeDetection bool                  `json:"disable_content_type_detection,omitempty"`
+}
+
+func (m *InputMediaPhoto) inputMediaType() string    { return "photo" }
+func (m *InputMediaVideo) inputMediaType() string    { return "video" }
+func (m *InputMediaAudio) inputMediaType() string    { return "audio" }
+func (m *InputMediaDocument) inputMediaType() string { return "document" }
+
+func (m *InputMediaPhoto) inputMediaFiles() map[string]IFileInfo {
+	return map[string]IFileInfo{"media": m.Media}
+}
+
+func (m *InputMediaVideo) inputMediaFiles() map[string]IFileInfo {
+	return map[string]IFileInfo{"media": m.Media, "thumbnail": m.Thumbnail, "cover": m.Cover}
+}
+
+func (m *InputMediaAudio) inputMediaFiles() map[string]IFileInfo {
+	return map[string]IFileInfo{"media": m.Media, "thumbnail": m.Thumbnail}
+}
+
+func (m *InputMediaDocument) inputMediaFiles() map[string]IFileInfo {
+	return map[string]IFileInfo{"media": m.Media, "thumbnail": m.Thumbnail}
+}
+
+type MediaGroupParams struct {
+	BusinessConnectionId string           `json:"business_connection_id,omitempty"`
+	ChatId               int              `json:"chat_id"`
+	MessageThreadId      int              `json:"message_thread_id,omitempty"`
+	Media                []IInputMedia    `json:"-"`
+	DisableNotification  bool             `json:"disable_notification,omitempty"`
+	ProtectContent       bool             `json:"protect_content,omitempty"`
+	AllowPaidBroadcast   bool             `json:"allow_paid_broadcast,omitempty"`
+	MessageEffectId      string           `json:"message_effect_id,omitempty"`
+	ReplyParameters      *ReplyParameters `json:"reply_parameters,omitempty"`
+}
+
+// encodeInputMedia encodes the media to JSON and replaces its files with
+// references. Files with a reader are referenced as "attach://<name>"
+// and returned to be uploaded in the same request under that name.
+func encodeInputMedia(index int, media IInputMedia) (json.RawMessage, []IFileInfo, error) {
+	b, err := json.Marshal(media)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj := map[string]any{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, nil, err
+	}
+	obj["type"] = media.inputMediaType()
+
+	uploads := []IFileInfo{}
+	for field, file := range media.inputMediaFiles() {
+		if isNilFile(file) {
+			if field == "media" {
+				return nil, nil, fmt.Errorf("media group item %d has no media", index)
+			}
+			continue
+		}
+		name, reader, err := file.UploadInfo()
+		if err != nil {
+			return nil, nil, err
+		}
+		if reader == nil {
+			obj[field] = name
+			continue
+		}
+		attachName := fmt.Sprintf("file%d_%s", index, field)
+		obj[field] = "attach://" + attachName
+		uploads = append(uploads, &FileReader{
+			Reader:   reader,
+			Kind:     attachName,
+			FileName: name,
+		})
+	}
+
+	b, err = json.Marshal(obj)
+	return b, uploads, err
+}
+
+// SendMediaGroup sends 2-10 photos, videos, documents or audios as an
+// album. Documents and audios can only be grouped with the same type.
+func (b *Bot) SendMediaGroup(ctx context.Context, params MediaGroupParams) ([]types.Message, error) {
+	if len(params.Media) == 0 {
+		return nil, errors.New("no media provided to send")
+	}
+	if len(params.Media) < minMediaGroupSize || len(params.Media) > maxMediaGroupSize {
+		return nil, fmt.Errorf("media group has %d items, %d to %d allowed",
+			len(params.Media), minMediaGroupSize, maxMediaGroupSize)
+	}
+
+	fields, err := ParamsToStringMap(&params)
+	if err != nil {
+		return nil, err
+	}
+
+	media := make([]json.RawMessage, 0, len(params.Media))
+	files := []IFileInfo{}
+	for i, m := range params.Media {
+		encoded, uploads, err := encodeInputMedia(i, m)
+		if err != nil {
+			return nil, err
+		}
+		media = append(media, encoded)
+		files = append(files, uploads...)
+	}
+	mediaJson, err := json.Marshal(media)
+	if err != nil {
+		return nil, err
+	}
+	fields["media"] = string(mediaJson)
+
+	apiResp, err := b.sendMultipart(ctx, MethodSendMediaGroup, fields, files)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := []types.Message{}
+	err = json.Unmarshal(apiResp.Result, &msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,52 @@
+package telbot
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSendMediaGroupSize(t *testing.T) {
+	photo := &InputMediaPhoto{Media: &FileId{Id: "x"}}
+	for _, n := range []int{0, 1, 11} {
+		media := make([]IInputMedia, n)
+		for i := range media {
+			media[i] = photo
+		}
+		// the size is checked before any request is sent
+		_, err := (&Bot{}).SendMediaGroup(context.Background(), MediaGroupParams{ChatId: 1, Media: media})
+		if err == nil {
+			t.Errorf("SendMediaGroup with %d items succeeded", n)
+		}
+	}
+}
+
+func TestEncodeInputMedia(t *testing.T) {
+	var thumbnail *FileReader
+	media := &InputMediaVideo{
+		Media:     &FileReader{Reader: strings.NewReader("video"), FileName: "video.mp4"},
+		Thumbnail: thumbnail,
+		Cover:     &FileUrl{Url: "https://example.com/cover.jpg"},
+		Caption:   "clip",
+	}
+	encoded, uploads, err := encodeInputMedia(3, media)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(encoded)
+	for _, want := range []string{`"type":"video"`, `"media":"attach://file3_media"`, `"cover":"https://example.com/cover.jpg"`, `"caption":"clip"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("encoded media %s doesn't contain %s", got, want)
+		}
+	}
+	if strings.Contains(got, "thumbnail") {
+		t.Errorf("nil thumbnail was encoded: %s", got)
+	}
+	if len(uploads) != 1 || uploads[0].FileKind() != "file3_media" {
+		t.Errorf("uploads = %+v, want only the video", uploads)
+	}
+
+	if _, _, err := encodeInputMedia(0, &InputMediaPhoto{Media: thumbnail}); err == nil {
+		t.Error("media without a file was encoded")
+	}
+}
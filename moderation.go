@@ -0,0 +1,238 @@
+package telbot
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+// unixTime converts t to a unix timestamp. The zero time is converted to 0
+// which Telegram treats as "forever".
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+type BanChatMemberParams struct {
+	ChatId int `json:"chat_id"`
+	UserId int `json:"user_id"`
+	// Zero value or a date less than 30 seconds or more than 366 days from
+	// now bans the user forever
+	UntilDate      time.Time `json:"-"`
+	RevokeMessages bool      `json:"revoke_messages,omitempty"`
+}
+
+func (p BanChatMemberParams) MarshalJSON() ([]byte, error) {
+	type alias BanChatMemberParams
+	return json.Marshal(struct {
+		alias
+		UntilDate int64 `json:"until_date,omitempty"`
+	}{alias(p), unixTime(p.UntilDate)})
+}
+
+type UnbanChatMemberParams struct {
+	ChatId int `json:"chat_id"`
+	UserId int `json:"user_id"`
+	// Do nothing if the user is not banned. Otherwise a member is removed
+	// from the chat too.
+	OnlyIfBanned bool `json:"only_if_banned,omitempty"`
+}
+
+type RestrictChatMemberParams struct {
+	ChatId                        int                   `json:"chat_id"`
+	UserId                        int                   `json:"user_id"`
+	Permissions                   types.ChatPermissions `json:"permissions"`
+	UseIndependentChatPermissions bool                  `json:"use_independent_chat_permissions,omitempty"`
+	// Zero value or a date less than 30 seconds or more than 366 days from
+	// now restricts the user forever
+	UntilDate time.Time `json:"-"`
+}
+
+func (p RestrictChatMemberParams) MarshalJSON() ([]byte, error) {
+	type alias RestrictChatMemberParams
+	return json.Marshal(struct {
+		alias
+		UntilDate int64 `json:"until_date,omitempty"`
+	}{alias(p), unixTime(p.UntilDate)})
+}
+
+// PromoteChatMemberParams grants the rights set to true. Passing all
+// rights as false demotes the user.
+type PromoteChatMemberParams struct {
+	ChatId int `json:"chat_id"`
+	UserId int `json:"user_id"`
+	types.ChatAdministratorRights
+}
+
+type SetChatPermissionsParams struct {
+	ChatId                        int                   `json:"chat_id"`
+	Permissions                   types.ChatPermissions `json:"permissions"`
+	UseIndependentChatPermissions bool                  `json:"use_independent_chat_permissions,omitempty"`
+}
+
+type SetChatAdministratorCustomTitleParams struct {
+	ChatId int `json:"chat_id"`
+	UserId int `json:"user_id"`
+	// 0-16 characters, emoji are not allowed
+	CustomTitle string `json:"custom_title"`
+}
+
+func (b *Bot) BanChatMember(ctx context.Context, params BanChatMemberParams) (bool, error) {
+	body, _ := ParamsToReader(params)
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodBanChatMember,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+func (b *Bot) UnbanChatMember(ctx context.Context, params UnbanChatMemberParams) (bool, error) {
+	body, _ := ParamsToReader(params)
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodUnbanChatMember,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+func (b *Bot) RestrictChatMember(ctx context.Context, params RestrictChatMemberParams) (bool, error) {
+	body, _ := ParamsToReader(params)
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodRestrictChatMember,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+func (b *Bot) PromoteChatMember(ctx context.Context, params PromoteChatMemberParams) (bool, error) {
+	body, _ := ParamsToReader(params)
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodPromoteChatMember,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+// SetChatPermissions sets default permissions for all members of a group
+// or supergroup
+func (b *Bot) SetChatPermissions(ctx context.Context, params SetChatPermissionsParams) (bool, error) {
+	body, _ := ParamsToReader(params)
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodSetChatPermissions,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+// SetChatAdministratorCustomTitle only works for administrators promoted
+// by the bot
+func (b *Bot) SetChatAdministratorCustomTitle(ctx context.Context, params SetChatAdministratorCustomTitleParams) (bool, error) {
+	body, _ := ParamsToReader(params)
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodSetChatAdministratorCustomTitle,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+// BanChatSenderChat bans a channel chat in a supergroup or a channel. The
+// owner of the banned chat can't send messages on behalf of any of their
+// channels until it's unbanned.
+func (b *Bot) BanChatSenderChat(ctx context.Context, chatId, senderChatId int) (bool, error) {
+	body, _ := ParamsToReader(map[string]int{"chat_id": chatId, "sender_chat_id": senderChatId})
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodBanChatSenderChat,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+func (b *Bot) UnbanChatSenderChat(ctx context.Context, chatId, senderChatId int) (bool, error) {
+	body, _ := ParamsToReader(map[string]int{"chat_id": chatId, "sender_chat_id": senderChatId})
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodUnbanChatSenderChat,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+// GetChatMember returns one of the types.ChatMember* types depending on
+// the member's status
+func (b *Bot) GetChatMember(ctx context.Context, chatId, userId int) (types.IChatMember, error) {
+	body, _ := ParamsToReader(map[string]int{"chat_id": chatId, "user_id": userId})
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodGetChatMember,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return types.UnmarshalChatMember(apiResp.Result)
+}
+
+// GetChatAdministrators returns administrators of a chat except other bots
+func (b *Bot) GetChatAdministrators(ctx context.Context, chatId int) ([]types.IChatMember, error) {
+	body, _ := ParamsToReader(map[string]int{"chat_id": chatId})
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodGetChatAdministrators,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw := []json.RawMessage{}
+	if err := json.Unmarshal(apiResp.Result, &raw); err != nil {
+		return nil, err
+	}
+	admins := make([]types.IChatMember, 0, len(raw))
+	for _, r := range raw {
+		member, err := types.UnmarshalChatMember(r)
+		if err != nil {
+			return nil, err
+		}
+		admins = append(admins, member)
+	}
+	return admins, nil
+}
+
+func (b *Bot) GetChatMemberCount(ctx context.Context, chatId int) (int, error) {
+	body, _ := ParamsToReader(map[string]int{"chat_id": chatId})
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodGetChatMemberCount,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = json.Unmarshal(apiResp.Result, &count)
+	return count, err
+}
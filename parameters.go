@@ -4,16 +4,27 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"reflect"
 	"strconv"
 
 	"github.com/thehxdev/telbot/types"
 )
 
+// IFileInfo describes a file sent with a multipart request. FileKind is
+// the name of the form field (e.g. "photo" or "document"). If the
+// returned reader is nil, the returned name is sent as the field value
+// instead of uploading a file. That's how file ids and URLs are sent.
 type IFileInfo interface {
 	UploadInfo() (string, io.Reader, error)
 	FileKind() string
 }
 
+// IUploadParams is implemented by parameters of methods that upload files
+type IUploadParams interface {
+	UploadMethod() string
+	ToStringMap() (map[string]string, error)
+}
+
 type FileReader struct {
 	io.Reader
 	Kind     string
@@ -28,11 +39,63 @@ func (fr *FileReader) FileKind() string {
 	return fr.Kind
 }
 
+// FileId refers to a file that already exists on telegram servers
+type FileId struct {
+	Kind string
+	Id   string
+}
+
+func (fi *FileId) UploadInfo() (string, io.Reader, error) {
+	return fi.Id, nil, nil
+}
+
+func (fi *FileId) FileKind() string {
+	return fi.Kind
+}
+
+// FileUrl refers to a file that telegram downloads from an HTTP URL
+type FileUrl struct {
+	Kind string
+	Url  string
+}
+
+func (fu *FileUrl) UploadInfo() (string, io.Reader, error) {
+	return fu.Url, nil, nil
+}
+
+func (fu *FileUrl) FileKind() string {
+	return fu.Kind
+}
+
+// overrides the kind of a file with the field name used by a method
+type fileWithKind struct {
+	IFileInfo
+	kind string
+}
+
+func (fk *fileWithKind) FileKind() string {
+	return fk.kind
+}
+
+func withKind(file IFileInfo, kind string) IFileInfo {
+	return &fileWithKind{IFileInfo: file, kind: kind}
+}
+
+// isNilFile reports whether file is nil or holds a nil pointer, like an
+// unset *FileReader assigned to an IFileInfo field
+func isNilFile(file IFileInfo) bool {
+	if file == nil {
+		return true
+	}
+	v := reflect.ValueOf(file)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
 type UpdateParams struct {
-	Offset         int      `json:"offset"`
-	Limit          int      `json:"limit"`
-	Timeout        int      `json:"timeout"`
-	AllowedUpdates []string `json:"allowed_updates"`
+	Offset         int          `json:"offset"`
+	Limit          int          `json:"limit"`
+	Timeout        int          `json:"timeout"`
+	AllowedUpdates []UpdateKind `json:"allowed_updates"`
 }
 
 type ReplyParameters struct {
@@ -45,7 +108,7 @@ type SuggestedPostParameters struct {
 	SendDate int                       `json:"send_date,omitempty"`
 }
 
-type IReplyMarkup any
+type IReplyMarkup = types.IReplyMarkup
 
 type TextMessageParams struct {
 	BusinessConnectionId    string                    `json:"business_connection_id,omitempty"`
@@ -61,8 +124,8 @@ type TextMessageParams struct {
 	SuggestedPostParameters *SuggestedPostParameters  `json:"suggested_post_parameters,omitempty"`
 	ReplyParameters         *ReplyParameters          `json:"reply_parameters,omitempty"`
 
-	// Must be one of "InlineKeyboardMarkup", "ReplyKeyboardMarkup", "ReplyKeyboardRemove"
-	// or "ForceReply" types
+	// Must be one of *types.InlineKeyboardMarkup, *types.ReplyKeyboardMarkup,
+	// *types.ReplyKeyboardRemove or *types.ForceReply
 	ReplyMarkup IReplyMarkup `json:"reply_markup,omitempty"`
 
 	// This field is not used anymore (I assume it's legacy. Use ReplyParams instead)
@@ -71,19 +134,58 @@ type TextMessageParams struct {
 }
 
 type EditMessageTextParams struct {
-	ChatId    int                   `json:"chat_id"`
-	MessageId int                   `json:"message_id"`
-	Text      string                `json:"text"`
-	ParseMode string                `json:"parse_mode,omitempty"`
-	Entities  []types.MessageEntity `json:"entities,omitempty"`
+	ChatId      int                         `json:"chat_id"`
+	MessageId   int                         `json:"message_id"`
+	Text        string                      `json:"text"`
+	ParseMode   string                      `json:"parse_mode,omitempty"`
+	Entities    []types.MessageEntity       `json:"entities,omitempty"`
+	ReplyMarkup *types.InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+type SetMessageReactionParams struct {
+	ChatId    int `json:"chat_id"`
+	MessageId int `json:"message_id"`
+	// Empty list removes the bot's reactions. Bots can't set paid
+	// reactions.
+	Reaction []types.IReactionType `json:"reaction"`
+	IsBig    bool                  `json:"is_big,omitempty"`
+}
+
+type AnswerCallbackQueryParams struct {
+	CallbackQueryId string `json:"callback_query_id"`
+	Text            string `json:"text,omitempty"`
+	ShowAlert       bool   `json:"show_alert,omitempty"`
+	Url             string `json:"url,omitempty"`
+	// Seconds the result may be cached on the client side
+	CacheTime int `json:"cache_time,omitempty"`
 }
 
+type AnswerInlineQueryParams struct {
+	InlineQueryId string                     `json:"inline_query_id"`
+	Results       []types.IInlineQueryResult `json:"results"`
+	// Seconds the result may be cached on the server. Zero uses the
+	// default of 300 seconds.
+	CacheTime  int                             `json:"cache_time,omitempty"`
+	IsPersonal bool                            `json:"is_personal,omitempty"`
+	NextOffset string                          `json:"next_offset,omitempty"`
+	Button     *types.InlineQueryResultsButton `json:"button,omitempty"`
+}
+
+// Generic upload parameters. Use the typed parameters (e.g. PhotoParams)
+// to send captions and other options along with the file.
 type UploadParams struct {
 	ChatId int    `json:"chat_id"`
 	Method string `json:"-"`
 }
 
-func (up *UploadParams) ToStringMap() (map[string]string, error) {
+func (up UploadParams) UploadMethod() string {
+	if up.Method == "" {
+		return MethodSendDocument
+	}
+	return up.Method
+}
+
+func (up UploadParams) ToStringMap() (map[string]string, error) {
 	p := map[string]string{}
 	if up.ChatId != 0 {
 		p["chat_id"] = strconv.Itoa(up.ChatId)
@@ -91,6 +193,34 @@ func (up *UploadParams) ToStringMap() (map[string]string, error) {
 	return p, nil
 }
 
+// ParamsToStringMap converts params to form fields. Strings are sent as
+// is and every other value (including nested objects) is JSON encoded.
+// Fields tagged with `json:"-"` are skipped.
+func ParamsToStringMap(params any) (map[string]string, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	p := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if len(value) > 0 && value[0] == '"' {
+			var str string
+			if err := json.Unmarshal(value, &str); err != nil {
+				return nil, err
+			}
+			p[key] = str
+			continue
+		}
+		p[key] = string(value)
+	}
+	return p, nil
+}
+
 func ParamsToReader(params any) (io.Reader, error) {
 	b, err := json.Marshal(params)
 	if err != nil {
@@ -0,0 +1,149 @@
+package telbot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"time"
+)
+
+// StartPolling fetches updates in a background goroutine and delivers
+// them to the returned channel. The channel is unbuffered, so an update
+// only counts as delivered once it was received from the channel. The
+// channel is closed once ctx is done or Stop is called. Updates that were
+// fetched but not received before that are not confirmed and will be
+// fetched again on the next start.
+func (b *Bot) StartPolling(ctx context.Context, params UpdateParams) (<-chan Update, error) {
+	b.pollingMu.Lock()
+	defer b.pollingMu.Unlock()
+
+	if b.pollingDone != nil {
+		select {
+		case <-b.pollingDone:
+		default:
+			return nil, errors.New("polling already started")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	updatesChan := make(chan Update)
+	done := make(chan struct{})
+	b.stopPolling = cancel
+	b.pollingDone = done
+
+	go func() {
+		defer close(done)
+		defer close(updatesChan)
+		defer cancel()
+		b.poll(ctx, params, updatesChan)
+	}()
+
+	return updatesChan, nil
+}
+
+// Stop cancels polling and waits for the polling goroutine to exit.
+func (b *Bot) Stop() {
+	b.pollingMu.Lock()
+	stop := b.stopPolling
+	b.pollingMu.Unlock()
+	if stop != nil {
+		stop()
+	}
+	b.Wait()
+}
+
+// Wait blocks until polling is stopped and the updates channel is closed.
+// Once it returns, every update was either received from the channel and
+// confirmed to the server, or will be fetched again on the next start.
+func (b *Bot) Wait() {
+	b.pollingMu.Lock()
+	done := b.pollingDone
+	b.pollingMu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+func (b *Bot) poll(ctx context.Context, params UpdateParams, updatesChan chan<- Update) {
+	startOffset := params.Offset
+	defer func() {
+		if params.Offset != startOffset {
+			b.confirmUpdates(params)
+		}
+	}()
+
+	failures := 0
+	for ctx.Err() == nil {
+		updates, err := b.GetUpdates(ctx, params)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			b.reportError(err)
+			failures++
+			delay := backoff(failures)
+			if apiErr, ok := asAPIError(err); ok {
+				delay = max(delay, apiErr.RetryAfter())
+			}
+			if sleepContext(ctx, delay) != nil {
+				return
+			}
+			continue
+		}
+		failures = 0
+
+		for _, update := range updates {
+			if update.Id < params.Offset {
+				continue
+			}
+			update.Bot = b
+			select {
+			case updatesChan <- update:
+				params.Offset = update.Id + 1
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		// long polling already waits on the server side
+		if params.Timeout == 0 && sleepContext(ctx, getUpdatesSleepTime) != nil {
+			return
+		}
+	}
+}
+
+// confirmUpdates marks updates received from the channel as processed on
+// the server, so they are not fetched again after a restart.
+func (b *Bot) confirmUpdates(params UpdateParams) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+	defer cancel()
+	params.Limit = 1
+	params.Timeout = 0
+	body, _ := ParamsToReader(params)
+	_, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodGetUpdates,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+	})
+	if err != nil {
+		b.reportError(err)
+	}
+}
+
+func (b *Bot) reportError(err error) {
+	if b.OnError != nil {
+		b.OnError(err)
+		return
+	}
+	log.Println(err)
+}
+
+// exponential backoff with jitter in [d/2, d)
+func backoff(failures int) time.Duration {
+	d := pollingMaxBackoff
+	if failures < 16 {
+		d = min(pollingMinBackoff<<(failures-1), pollingMaxBackoff)
+	}
+	return d/2 + rand.N(d/2)
+}
@@ -0,0 +1,179 @@
+package telbot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// updatesServer serves getUpdates with the updates 10, 11 and 12. Idle long
+// polls block until the client gives up. Requests with a zero timeout and
+// a limit of one are recorded as confirmations.
+type updatesServer struct {
+	mu       sync.Mutex
+	failures int
+	confirms []int
+}
+
+func (s *updatesServer) handle(w http.ResponseWriter, r *http.Request) {
+	params := UpdateParams{}
+	json.NewDecoder(r.Body).Decode(&params)
+
+	s.mu.Lock()
+	if params.Timeout == 0 && params.Limit == 1 {
+		s.confirms = append(s.confirms, params.Offset)
+		s.mu.Unlock()
+		writeResult(w, []Update{})
+		return
+	}
+	if s.failures > 0 {
+		s.failures--
+		s.mu.Unlock()
+		writeError(w, APIResponse{ErrorCode: http.StatusInternalServerError, Description: "Internal Server Error"})
+		return
+	}
+	s.mu.Unlock()
+
+	updates := []Update{}
+	for id := max(params.Offset, 10); id <= 12; id++ {
+		updates = append(updates, Update{Id: id})
+	}
+	if len(updates) == 0 {
+		<-r.Context().Done()
+		return
+	}
+	writeResult(w, updates)
+}
+
+func (s *updatesServer) confirmed() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int{}, s.confirms...)
+}
+
+func newPollingBot(t *testing.T, s *updatesServer) *Bot {
+	bot, _ := newTestBot(t, map[string]http.HandlerFunc{MethodGetUpdates: s.handle})
+	return bot
+}
+
+func receive(t *testing.T, updates <-chan Update) Update {
+	t.Helper()
+	select {
+	case update, ok := <-updates:
+		if !ok {
+			t.Fatal("updates channel was closed")
+		}
+		return update
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update received")
+	}
+	return Update{}
+}
+
+func assertClosed(t *testing.T, updates <-chan Update) {
+	t.Helper()
+	select {
+	case update, ok := <-updates:
+		if ok {
+			t.Fatalf("received update %d after polling stopped", update.Id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("updates channel was not closed")
+	}
+}
+
+func TestPollingConfirmsReceivedUpdates(t *testing.T) {
+	s := &updatesServer{}
+	bot := newPollingBot(t, s)
+	updates, err := bot.StartPolling(context.Background(), UpdateParams{Timeout: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.StartPolling(context.Background(), UpdateParams{}); err == nil {
+		t.Error("polling started twice")
+	}
+
+	for _, id := range []int{10, 11} {
+		update := receive(t, updates)
+		if update.Id != id || update.Bot != bot {
+			t.Fatalf("received update %d with bot %p, want %d with %p", update.Id, update.Bot, id, bot)
+		}
+	}
+	bot.Stop()
+	assertClosed(t, updates)
+
+	// update 12 was fetched but never received
+	if got := s.confirmed(); len(got) != 1 || got[0] != 12 {
+		t.Errorf("confirmed offsets %v, want [12]", got)
+	}
+
+	// polling can be started again once stopped
+	updates, err = bot.StartPolling(context.Background(), UpdateParams{Offset: 12, Timeout: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update := receive(t, updates); update.Id != 12 {
+		t.Errorf("received update %d after restart, want 12", update.Id)
+	}
+	bot.Stop()
+}
+
+func TestPollingStopsOnCancel(t *testing.T) {
+	s := &updatesServer{}
+	bot := newPollingBot(t, s)
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := bot.StartPolling(ctx, UpdateParams{Timeout: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	assertClosed(t, updates)
+	bot.Wait()
+	if got := s.confirmed(); len(got) != 0 {
+		t.Errorf("confirmed offsets %v without receiving updates", got)
+	}
+}
+
+func TestPollingRetriesAfterErrors(t *testing.T) {
+	s := &updatesServer{failures: 1}
+	bot := newPollingBot(t, s)
+	errs := make(chan error, 1)
+	bot.OnError = func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+	updates, err := bot.StartPolling(context.Background(), UpdateParams{Timeout: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bot.Stop()
+
+	if update := receive(t, updates); update.Id != 10 {
+		t.Errorf("received update %d, want 10", update.Id)
+	}
+	select {
+	case err := <-errs:
+		if apiErr, ok := asAPIError(err); !ok || apiErr.Code != http.StatusInternalServerError {
+			t.Errorf("OnError got %v, want the server error", err)
+		}
+	default:
+		t.Error("OnError was not called")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for failures := 1; failures <= 20; failures++ {
+		d := min(pollingMinBackoff<<min(failures-1, 15), pollingMaxBackoff)
+		for range 100 {
+			if got := backoff(failures); got < d/2 || got >= d {
+				t.Fatalf("backoff(%d) = %v, want in [%v, %v)", failures, got, d/2, d)
+			}
+		}
+	}
+}
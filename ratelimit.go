@@ -0,0 +1,161 @@
+package telbot
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	globalRateLimit   = 30
+	privateChatLimit  = 1
+	groupChatLimit    = 20
+	defaultMaxRetries = 3
+	chatBucketsPrune  = 1024
+)
+
+// RateLimiter throttles outgoing requests to stay within telegram's
+// broadcasting limits and retries requests rejected by flood control.
+// Set `Bot.Limiter` to enable it. The zero value uses telegram's default
+// limits but doesn't retry requests; use NewRateLimiter to get both.
+type RateLimiter struct {
+	// Maximum number of times a request is repeated after a flood wait
+	// error. Only requests with a seekable body (all JSON requests) are
+	// repeated; multipart uploads are streamed and can't be retried.
+	MaxRetries int
+
+	mu     sync.Mutex
+	global *bucket
+	chats  map[int]*bucket
+}
+
+// token bucket refilled continuously at `rate` tokens per second
+type bucket struct {
+	tokens float64
+	burst  float64
+	rate   float64
+	last   time.Time
+}
+
+func newBucket(burst int, per time.Duration) *bucket {
+	return &bucket{
+		tokens: float64(burst),
+		burst:  float64(burst),
+		rate:   float64(burst) / per.Seconds(),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait
+// before using it.
+func (bk *bucket) reserve(now time.Time) time.Duration {
+	if !bk.last.IsZero() {
+		bk.tokens = min(bk.burst, bk.tokens+now.Sub(bk.last).Seconds()*bk.rate)
+	}
+	bk.last = now
+	bk.tokens--
+	if bk.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bk.tokens / bk.rate * float64(time.Second))
+}
+
+func (bk *bucket) idle(now time.Time) bool {
+	return bk.tokens+now.Sub(bk.last).Seconds()*bk.rate >= bk.burst
+}
+
+// Create a new RateLimiter with telegram's default limits: 30 messages
+// per second overall, 1 message per second in a private chat and 20
+// messages per minute in a group.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		MaxRetries: defaultMaxRetries,
+		global:     newBucket(globalRateLimit, time.Second),
+		chats:      make(map[int]*bucket),
+	}
+}
+
+// Wait blocks until a message can be sent to chatId or ctx is done.
+func (rl *RateLimiter) Wait(ctx context.Context, chatId int) error {
+	rl.mu.Lock()
+	if rl.global == nil {
+		rl.global = newBucket(globalRateLimit, time.Second)
+	}
+	if rl.chats == nil {
+		rl.chats = make(map[int]*bucket)
+	}
+	now := time.Now()
+	if len(rl.chats) >= chatBucketsPrune {
+		for id, bk := range rl.chats {
+			if bk.idle(now) {
+				delete(rl.chats, id)
+			}
+		}
+	}
+	chat, ok := rl.chats[chatId]
+	if !ok {
+		// group and channel ids are negative
+		if chatId < 0 {
+			chat = newBucket(groupChatLimit, time.Minute)
+		} else {
+			chat = newBucket(privateChatLimit, time.Second)
+		}
+		rl.chats[chatId] = chat
+	}
+	delay := max(rl.global.reserve(now), chat.reserve(now))
+	rl.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		// give the reserved tokens back
+		rl.mu.Lock()
+		rl.global.tokens++
+		chat.tokens++
+		rl.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (rl *RateLimiter) do(ctx context.Context, info RequestInfo, send func() (APIResponse, error)) (APIResponse, error) {
+	for attempt := 0; ; attempt++ {
+		if info.ChatId != 0 {
+			if err := rl.Wait(ctx, info.ChatId); err != nil {
+				return APIResponse{}, err
+			}
+		}
+		resp, err := send()
+		apiErr, ok := asAPIError(err)
+		if !ok || apiErr.RetryAfter() == 0 || attempt >= rl.MaxRetries || !rewind(info.Body) {
+			return resp, err
+		}
+		if err := sleepContext(ctx, apiErr.RetryAfter()); err != nil {
+			return resp, err
+		}
+	}
+}
+
+func rewind(body io.Reader) bool {
+	if body == nil {
+		return true
+	}
+	seeker, ok := body.(io.Seeker)
+	if !ok {
+		return false
+	}
+	_, err := seeker.Seek(0, io.SeekStart)
+	return err == nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+package telbot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+func TestBucket(t *testing.T) {
+	now := time.Now()
+	bk := newBucket(2, time.Second)
+	for i := 0; i < 2; i++ {
+		if d := bk.reserve(now); d != 0 {
+			t.Fatalf("reserve %d: waited %v within the burst", i, d)
+		}
+	}
+	if d := bk.reserve(now); d != 500*time.Millisecond {
+		t.Errorf("reserve after the burst = %v, want 500ms", d)
+	}
+	if bk.idle(now.Add(time.Second)) {
+		t.Error("bucket is idle before the reserved tokens are refilled")
+	}
+	if !bk.idle(now.Add(2 * time.Second)) {
+		t.Error("bucket is not idle after refilling")
+	}
+	if d := bk.reserve(now.Add(2 * time.Second)); d != 0 {
+		t.Errorf("reserve after refilling = %v, want 0", d)
+	}
+}
+
+func TestRateLimiterZeroValue(t *testing.T) {
+	rl := &RateLimiter{}
+	if err := rl.Wait(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := rl.Wait(context.Background(), -1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	rl := NewRateLimiter()
+	ctx := context.Background()
+	if err := rl.Wait(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// the private chat limit is one message per second
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := rl.Wait(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait = %v, want context.DeadlineExceeded", err)
+	}
+	if tokens := rl.chats[1].tokens; tokens < -0.01 {
+		t.Errorf("canceled Wait kept its token: %v tokens left", tokens)
+	}
+}
+
+func floodError(seconds int) error {
+	return &APIError{
+		Code:        429,
+		Description: "Too Many Requests",
+		Parameters:  &types.ResponseParameters{RetryAfter: seconds},
+	}
+}
+
+func TestRateLimiterRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		body       *bytes.Reader
+		errs       []error
+		wantCalls  int
+		wantErr    bool
+	}{
+		{"success", 3, nil, []error{nil}, 1, false},
+		{"other error", 3, nil, []error{errors.New("boom")}, 1, true},
+		{"retried", 3, bytes.NewReader([]byte("{}")), []error{floodError(1), nil}, 2, false},
+		{"out of retries", 0, nil, []error{floodError(1)}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := &RateLimiter{MaxRetries: tt.maxRetries}
+			info := RequestInfo{Method: MethodSendMessage}
+			if tt.body != nil {
+				info.Body = tt.body
+			}
+			calls := 0
+			_, err := rl.do(context.Background(), info, func() (APIResponse, error) {
+				err := tt.errs[calls]
+				calls++
+				if tt.body != nil {
+					if tt.body.Len() != 2 {
+						t.Error("body was not rewound before retrying")
+					}
+					io.Copy(io.Discard, tt.body)
+				}
+				return APIResponse{}, err
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("send called %d times, want %d", calls, tt.wantCalls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRateLimiterRetryCanceled(t *testing.T) {
+	rl := NewRateLimiter()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	calls := 0
+	_, err := rl.do(ctx, RequestInfo{}, func() (APIResponse, error) {
+		calls++
+		return APIResponse{}, floodError(5)
+	})
+	if !errors.Is(err, context.DeadlineExceeded) || calls != 1 {
+		t.Errorf("do() = %v after %d calls, want context.DeadlineExceeded after 1", err, calls)
+	}
+}
+
+func TestRateLimiterStreamedBody(t *testing.T) {
+	rl := NewRateLimiter()
+	calls := 0
+	_, err := rl.do(context.Background(), RequestInfo{Body: bytes.NewBufferString("x")}, func() (APIResponse, error) {
+		calls++
+		return APIResponse{}, floodError(1)
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("do() = %v after %d calls, want the flood error after 1", err, calls)
+	}
+}
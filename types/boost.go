@@ -0,0 +1,29 @@
+package types
+
+// Source is one of "premium", "gift_code" or "giveaway"
+type ChatBoostSource struct {
+	Source            string `json:"source"`
+	User              *User  `json:"user,omitempty"`
+	GiveawayMessageId int    `json:"giveaway_message_id,omitempty"`
+	PrizeStarCount    int    `json:"prize_star_count,omitempty"`
+	IsUnclaimed       bool   `json:"is_unclaimed,omitempty"`
+}
+
+type ChatBoost struct {
+	BoostId        string          `json:"boost_id"`
+	AddDate        int64           `json:"add_date"`
+	ExpirationDate int64           `json:"expiration_date"`
+	Source         ChatBoostSource `json:"source"`
+}
+
+type ChatBoostUpdated struct {
+	Chat  Chat      `json:"chat"`
+	Boost ChatBoost `json:"boost"`
+}
+
+type ChatBoostRemoved struct {
+	Chat       Chat            `json:"chat"`
+	BoostId    string          `json:"boost_id"`
+	RemoveDate int64           `json:"remove_date"`
+	Source     ChatBoostSource `json:"source"`
+}
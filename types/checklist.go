@@ -0,0 +1,30 @@
+package types
+
+type ChecklistTask struct {
+	Id              int             `json:"id"`
+	Text            string          `json:"text"`
+	TextEntities    []MessageEntity `json:"text_entities,omitempty"`
+	CompletedByUser *User           `json:"completed_by_user,omitempty"`
+	CompletedByChat *Chat           `json:"completed_by_chat,omitempty"`
+	// 0 if the task isn't completed
+	CompletionDate int64 `json:"completion_date,omitempty"`
+}
+
+type Checklist struct {
+	Title                  string          `json:"title"`
+	TitleEntities          []MessageEntity `json:"title_entities,omitempty"`
+	Tasks                  []ChecklistTask `json:"tasks"`
+	OthersCanAddTasks      bool            `json:"others_can_add_tasks,omitempty"`
+	OthersCanMarkTasksDone bool            `json:"others_can_mark_tasks_as_done,omitempty"`
+}
+
+type ChecklistTasksDone struct {
+	ChecklistMessage       *Message `json:"checklist_message,omitempty"`
+	MarkedAsDoneTaskIds    []int    `json:"marked_as_done_task_ids,omitempty"`
+	MarkedAsNotDoneTaskIds []int    `json:"marked_as_not_done_task_ids,omitempty"`
+}
+
+type ChecklistTasksAdded struct {
+	ChecklistMessage *Message        `json:"checklist_message,omitempty"`
+	Tasks            []ChecklistTask `json:"tasks"`
+}
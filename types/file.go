@@ -22,3 +22,79 @@ type PhotoSize struct {
 	Height       int    `json:"height"`
 	FileSize     int    `json:"file_size,omitempty"`
 }
+
+type Animation struct {
+	FileId       string     `json:"file_id"`
+	FileUniqueId string     `json:"file_unique_id"`
+	Width        int        `json:"width"`
+	Height       int        `json:"height"`
+	Duration     int        `json:"duration"`
+	Thumbnail    *PhotoSize `json:"thumbnail,omitempty"`
+	FileName     string     `json:"file_name,omitempty"`
+	MimeType     string     `json:"mime_type,omitempty"`
+	FileSize     int        `json:"file_size,omitempty"`
+}
+
+type Audio struct {
+	FileId       string     `json:"file_id"`
+	FileUniqueId string     `json:"file_unique_id"`
+	Duration     int        `json:"duration"`
+	Performer    string     `json:"performer,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	FileName     string     `json:"file_name,omitempty"`
+	MimeType     string     `json:"mime_type,omitempty"`
+	FileSize     int        `json:"file_size,omitempty"`
+	Thumbnail    *PhotoSize `json:"thumbnail,omitempty"`
+}
+
+type Video struct {
+	FileId         string      `json:"file_id"`
+	FileUniqueId   string      `json:"file_unique_id"`
+	Width          int         `json:"width"`
+	Height         int         `json:"height"`
+	Duration       int         `json:"duration"`
+	Thumbnail      *PhotoSize  `json:"thumbnail,omitempty"`
+	Cover          []PhotoSize `json:"cover,omitempty"`
+	StartTimestamp int         `json:"start_timestamp,omitempty"`
+	FileName       string      `json:"file_name,omitempty"`
+	MimeType       string      `json:"mime_type,omitempty"`
+	FileSize       int         `json:"file_size,omitempty"`
+}
+
+type VideoNote struct {
+	FileId       string     `json:"file_id"`
+	FileUniqueId string     `json:"file_unique_id"`
+	Length       int        `json:"length"`
+	Duration     int        `json:"duration"`
+	Thumbnail    *PhotoSize `json:"thumbnail,omitempty"`
+	FileSize     int        `json:"file_size,omitempty"`
+}
+
+type Voice struct {
+	FileId       string `json:"file_id"`
+	FileUniqueId string `json:"file_unique_id"`
+	Duration     int    `json:"duration"`
+	MimeType     string `json:"mime_type,omitempty"`
+	FileSize     int    `json:"file_size,omitempty"`
+}
+
+type Story struct {
+	Chat Chat `json:"chat"`
+	Id   int  `json:"id"`
+}
+
+// PaidMedia is one of "preview", "photo" or "video" depending on Type.
+// Preview media only has the dimensions and duration set.
+type PaidMedia struct {
+	Type     string      `json:"type"`
+	Width    int         `json:"width,omitempty"`
+	Height   int         `json:"height,omitempty"`
+	Duration int         `json:"duration,omitempty"`
+	Photo    []PhotoSize `json:"photo,omitempty"`
+	Video    *Video      `json:"video,omitempty"`
+}
+
+type PaidMediaInfo struct {
+	StarCount int         `json:"star_count"`
+	PaidMedia []PaidMedia `json:"paid_media"`
+}
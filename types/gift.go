@@ -0,0 +1,72 @@
+package types
+
+type Gift struct {
+	Id               string  `json:"id"`
+	Sticker          Sticker `json:"sticker"`
+	StarCount        int     `json:"star_count"`
+	UpgradeStarCount int     `json:"upgrade_star_count,omitempty"`
+	TotalCount       int     `json:"total_count,omitempty"`
+	RemainingCount   int     `json:"remaining_count,omitempty"`
+	PublisherChat    *Chat   `json:"publisher_chat,omitempty"`
+}
+
+// GiftInfo describes a service message about a regular gift that was sent
+// or received
+type GiftInfo struct {
+	Gift                    Gift            `json:"gift"`
+	OwnedGiftId             string          `json:"owned_gift_id,omitempty"`
+	ConvertStarCount        int             `json:"convert_star_count,omitempty"`
+	PrepaidUpgradeStarCount int             `json:"prepaid_upgrade_star_count,omitempty"`
+	CanBeUpgraded           bool            `json:"can_be_upgraded,omitempty"`
+	Text                    string          `json:"text,omitempty"`
+	Entities                []MessageEntity `json:"entities,omitempty"`
+	IsPrivate               bool            `json:"is_private,omitempty"`
+}
+
+type UniqueGiftModel struct {
+	Name           string  `json:"name"`
+	Sticker        Sticker `json:"sticker"`
+	RarityPerMille int     `json:"rarity_per_mille"`
+}
+
+type UniqueGiftSymbol struct {
+	Name           string  `json:"name"`
+	Sticker        Sticker `json:"sticker"`
+	RarityPerMille int     `json:"rarity_per_mille"`
+}
+
+// Colors are in RGB24 format
+type UniqueGiftBackdropColors struct {
+	CenterColor int `json:"center_color"`
+	EdgeColor   int `json:"edge_color"`
+	SymbolColor int `json:"symbol_color"`
+	TextColor   int `json:"text_color"`
+}
+
+type UniqueGiftBackdrop struct {
+	Name           string                   `json:"name"`
+	Colors         UniqueGiftBackdropColors `json:"colors"`
+	RarityPerMille int                      `json:"rarity_per_mille"`
+}
+
+type UniqueGift struct {
+	BaseName      string             `json:"base_name"`
+	Name          string             `json:"name"`
+	Number        int                `json:"number"`
+	Model         UniqueGiftModel    `json:"model"`
+	Symbol        UniqueGiftSymbol   `json:"symbol"`
+	Backdrop      UniqueGiftBackdrop `json:"backdrop"`
+	PublisherChat *Chat              `json:"publisher_chat,omitempty"`
+}
+
+// UniqueGiftInfo describes a service message about a unique gift that was
+// sent or received
+type UniqueGiftInfo struct {
+	Gift UniqueGift `json:"gift"`
+	// One of "upgrade", "transfer" or "resale"
+	Origin              string `json:"origin"`
+	LastResaleStarCount int    `json:"last_resale_star_count,omitempty"`
+	OwnedGiftId         string `json:"owned_gift_id,omitempty"`
+	TransferStarCount   int    `json:"transfer_star_count,omitempty"`
+	NextTransferDate    int64  `json:"next_transfer_date,omitempty"`
+}
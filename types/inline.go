@@ -1,5 +1,7 @@
 package types
 
+import "strconv"
+
 type InlineQuery struct {
 	Id       string
 	From     User
@@ -17,6 +19,10 @@ type ChosenInlineResult struct {
 	Query           string
 }
 
+// CallbackQuery is sent when a user presses an inline keyboard button.
+// This package can't send requests, so the helpers that answer a query
+// live on telbot.Update (AnswerCallback, AnswerCallbackAlert and
+// AnswerCallbackUrl); use Bot.AnswerCallbackQuery for the other options.
 type CallbackQuery struct {
 	Id              string
 	From            User
@@ -26,3 +32,24 @@ type CallbackQuery struct {
 	Data            string
 	GameShortName   string `json:"game_short_name,omitempty"`
 }
+
+// telegram accepts at most 50 results per answer to an inline query
+const maxInlineResults = 50
+
+// Page returns the bounds of the requested page out of total results,
+// using Offset as the index of the first result. nextOffset is the value
+// for `next_offset`, empty when there are no more results. pageSize is
+// clamped to 1..50.
+func (q *InlineQuery) Page(total, pageSize int) (start, end int, nextOffset string) {
+	pageSize = min(max(pageSize, 1), maxInlineResults)
+	start, err := strconv.Atoi(q.Offset)
+	if err != nil || start < 0 {
+		start = 0
+	}
+	start = min(start, total)
+	end = min(start+pageSize, total)
+	if end < total {
+		nextOffset = strconv.Itoa(end)
+	}
+	return start, end, nextOffset
+}
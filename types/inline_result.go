@@ -0,0 +1,517 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// IInlineQueryResult is implemented by all InlineQueryResult* types
+type IInlineQueryResult interface {
+	isInlineQueryResult()
+}
+
+// IInputMessageContent is implemented by all Input*MessageContent types
+type IInputMessageContent interface {
+	isInputMessageContent()
+}
+
+// marshalWithType encodes v and adds the "type" discriminator
+func marshalWithType(typ string, v any) ([]byte, error) {
+	return marshalWithKey("type", typ, v)
+}
+
+// marshalWithKey marshals v and adds key with the given value to the
+// resulting object
+func marshalWithKey(key, value string, v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+	obj[key], _ = json.Marshal(value)
+	return json.Marshal(obj)
+}
+
+// unmarshalWithKey decodes data into the type registered in known for the
+// string under key. Values missing from known are decoded into the result
+// of unknown, which receives a copy of data.
+func unmarshalWithKey[T any](data []byte, key string, known map[string]func() T, unknown func(raw json.RawMessage) T) (T, error) {
+	var zero T
+	obj := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return zero, err
+	}
+	value := ""
+	if raw, ok := obj[key]; ok {
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return zero, err
+		}
+	}
+
+	var v T
+	if newValue, ok := known[value]; ok {
+		v = newValue()
+	} else {
+		v = unknown(bytes.Clone(data))
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+type InlineQueryResultsButton struct {
+	Text           string      `json:"text"`
+	WebApp         *WebAppInfo `json:"web_app,omitempty"`
+	StartParameter string      `json:"start_parameter,omitempty"`
+}
+
+type InputTextMessageContent struct {
+	MessageText        string              `json:"message_text"`
+	ParseMode          string              `json:"parse_mode,omitempty"`
+	Entities           []MessageEntity     `json:"entities,omitempty"`
+	LinkPreviewOptions *LinkPreviewOptions `json:"link_preview_options,omitempty"`
+}
+
+type InputLocationMessageContent struct {
+	Latitude             float32 `json:"latitude"`
+	Longitude            float32 `json:"longitude"`
+	HorizontalAccuracy   float32 `json:"horizontal_accuracy,omitempty"`
+	LivePeriod           int     `json:"live_period,omitempty"`
+	Heading              int     `json:"heading,omitempty"`
+	ProximityAlertRadius int     `json:"proximity_alert_radius,omitempty"`
+}
+
+type InputVenueMessageContent struct {
+	Latitude        float32 `json:"latitude"`
+	Longitude       float32 `json:"longitude"`
+	Title           string  `json:"title"`
+	Address         string  `json:"address"`
+	FoursquareId    string  `json:"foursquare_id,omitempty"`
+	FoursquareType  string  `json:"foursquare_type,omitempty"`
+	GooglePlaceId   string  `json:"google_place_id,omitempty"`
+	GooglePlaceType string  `json:"google_place_type,omitempty"`
+}
+
+type InputContactMessageContent struct {
+	PhoneNumber string `json:"phone_number"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name,omitempty"`
+	Vcard       string `json:"vcard,omitempty"`
+}
+
+type InputInvoiceMessageContent struct {
+	Title                     string         `json:"title"`
+	Description               string         `json:"description"`
+	Payload                   string         `json:"payload"`
+	ProviderToken             string         `json:"provider_token,omitempty"`
+	Currency                  string         `json:"currency"`
+	Prices                    []LabeledPrice `json:"prices"`
+	MaxTipAmount              int            `json:"max_tip_amount,omitempty"`
+	SuggestedTipAmounts       []int          `json:"suggested_tip_amounts,omitempty"`
+	ProviderData              string         `json:"provider_data,omitempty"`
+	PhotoUrl                  string         `json:"photo_url,omitempty"`
+	PhotoSize                 int            `json:"photo_size,omitempty"`
+	PhotoWidth                int            `json:"photo_width,omitempty"`
+	PhotoHeight               int            `json:"photo_height,omitempty"`
+	NeedName                  bool           `json:"need_name,omitempty"`
+	NeedPhoneNumber           bool           `json:"need_phone_number,omitempty"`
+	NeedEmail                 bool           `json:"need_email,omitempty"`
+	NeedShippingAddress       bool           `json:"need_shipping_address,omitempty"`
+	SendPhoneNumberToProvider bool           `json:"send_phone_number_to_provider,omitempty"`
+	SendEmailToProvider       bool           `json:"send_email_to_provider,omitempty"`
+	IsFlexible                bool           `json:"is_flexible,omitempty"`
+}
+
+func (*InputTextMessageContent) isInputMessageContent()     {}
+func (*InputLocationMessageContent) isInputMessageContent() {}
+func (*InputVenueMessageContent) isInputMessageContent()    {}
+func (*InputContactMessageContent) isInputMessageContent()  {}
+func (*InputInvoiceMessageContent) isInputMessageContent()  {}
+
+type InlineQueryResultArticle struct {
+	Id                  string                `json:"id"`
+	Title               string                `json:"title"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	Url                 string                `json:"url,omitempty"`
+	Description         string                `json:"description,omitempty"`
+	ThumbnailUrl        string                `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth      int                   `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight     int                   `json:"thumbnail_height,omitempty"`
+}
+
+type InlineQueryResultPhoto struct {
+	Id                    string                `json:"id"`
+	PhotoUrl              string                `json:"photo_url"`
+	ThumbnailUrl          string                `json:"thumbnail_url"`
+	PhotoWidth            int                   `json:"photo_width,omitempty"`
+	PhotoHeight           int                   `json:"photo_height,omitempty"`
+	Title                 string                `json:"title,omitempty"`
+	Description           string                `json:"description,omitempty"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity       `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent   IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultGif struct {
+	Id                    string                `json:"id"`
+	GifUrl                string                `json:"gif_url"`
+	GifWidth              int                   `json:"gif_width,omitempty"`
+	GifHeight             int                   `json:"gif_height,omitempty"`
+	GifDuration           int                   `json:"gif_duration,omitempty"`
+	ThumbnailUrl          string                `json:"thumbnail_url"`
+	ThumbnailMimeType     string                `json:"thumbnail_mime_type,omitempty"`
+	Title                 string                `json:"title,omitempty"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity       `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent   IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultMpeg4Gif struct {
+	Id                    string                `json:"id"`
+	Mpeg4Url              string                `json:"mpeg4_url"`
+	Mpeg4Width            int                   `json:"mpeg4_width,omitempty"`
+	Mpeg4Height           int                   `json:"mpeg4_height,omitempty"`
+	Mpeg4Duration         int                   `json:"mpeg4_duration,omitempty"`
+	ThumbnailUrl          string                `json:"thumbnail_url"`
+	ThumbnailMimeType     string                `json:"thumbnail_mime_type,omitempty"`
+	Title                 string                `json:"title,omitempty"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity       `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent   IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultVideo struct {
+	Id                    string                `json:"id"`
+	VideoUrl              string                `json:"video_url"`
+	MimeType              string                `json:"mime_type"`
+	ThumbnailUrl          string                `json:"thumbnail_url"`
+	Title                 string                `json:"title"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity       `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	VideoWidth            int                   `json:"video_width,omitempty"`
+	VideoHeight           int                   `json:"video_height,omitempty"`
+	VideoDuration         int                   `json:"video_duration,omitempty"`
+	Description           string                `json:"description,omitempty"`
+	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent   IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultAudio struct {
+	Id                  string                `json:"id"`
+	AudioUrl            string                `json:"audio_url"`
+	Title               string                `json:"title"`
+	Caption             string                `json:"caption,omitempty"`
+	ParseMode           string                `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity       `json:"caption_entities,omitempty"`
+	Performer           string                `json:"performer,omitempty"`
+	AudioDuration       int                   `json:"audio_duration,omitempty"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultVoice struct {
+	Id                  string                `json:"id"`
+	VoiceUrl            string                `json:"voice_url"`
+	Title               string                `json:"title"`
+	Caption             string                `json:"caption,omitempty"`
+	ParseMode           string                `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity       `json:"caption_entities,omitempty"`
+	VoiceDuration       int                   `json:"voice_duration,omitempty"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultDocument struct {
+	Id                  string                `json:"id"`
+	Title               string                `json:"title"`
+	Caption             string                `json:"caption,omitempty"`
+	ParseMode           string                `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity       `json:"caption_entities,omitempty"`
+	DocumentUrl         string                `json:"document_url"`
+	MimeType            string                `json:"mime_type"`
+	Description         string                `json:"description,omitempty"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content,omitempty"`
+	ThumbnailUrl        string                `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth      int                   `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight     int                   `json:"thumbnail_height,omitempty"`
+}
+
+type InlineQueryResultLocation struct {
+	Id                   string                `json:"id"`
+	Latitude             float32               `json:"latitude"`
+	Longitude            float32               `json:"longitude"`
+	Title                string                `json:"title"`
+	HorizontalAccuracy   float32               `json:"horizontal_accuracy,omitempty"`
+	LivePeriod           int                   `json:"live_period,omitempty"`
+	Heading              int                   `json:"heading,omitempty"`
+	ProximityAlertRadius int                   `json:"proximity_alert_radius,omitempty"`
+	ReplyMarkup          *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent  IInputMessageContent  `json:"input_message_content,omitempty"`
+	ThumbnailUrl         string                `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth       int                   `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight      int                   `json:"thumbnail_height,omitempty"`
+}
+
+type InlineQueryResultVenue struct {
+	Id                  string                `json:"id"`
+	Latitude            float32               `json:"latitude"`
+	Longitude           float32               `json:"longitude"`
+	Title               string                `json:"title"`
+	Address             string                `json:"address"`
+	FoursquareId        string                `json:"foursquare_id,omitempty"`
+	FoursquareType      string                `json:"foursquare_type,omitempty"`
+	GooglePlaceId       string                `json:"google_place_id,omitempty"`
+	GooglePlaceType     string                `json:"google_place_type,omitempty"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content,omitempty"`
+	ThumbnailUrl        string                `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth      int                   `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight     int                   `json:"thumbnail_height,omitempty"`
+}
+
+type InlineQueryResultContact struct {
+	Id                  string                `json:"id"`
+	PhoneNumber         string                `json:"phone_number"`
+	FirstName           string                `json:"first_name"`
+	LastName            string                `json:"last_name,omitempty"`
+	Vcard               string                `json:"vcard,omitempty"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content,omitempty"`
+	ThumbnailUrl        string                `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth      int                   `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight     int                   `json:"thumbnail_height,omitempty"`
+}
+
+type InlineQueryResultGame struct {
+	Id            string                `json:"id"`
+	GameShortName string                `json:"game_short_name"`
+	ReplyMarkup   *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+type InlineQueryResultCachedPhoto struct {
+	Id                    string                `json:"id"`
+	PhotoFileId           string                `json:"photo_file_id"`
+	Title                 string                `json:"title,omitempty"`
+	Description           string                `json:"description,omitempty"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity       `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent   IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultCachedGif struct {
+	Id                    string                `json:"id"`
+	GifFileId             string                `json:"gif_file_id"`
+	Title                 string                `json:"title,omitempty"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity       `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent   IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultCachedMpeg4Gif struct {
+	Id                    string                `json:"id"`
+	Mpeg4FileId           string                `json:"mpeg4_file_id"`
+	Title                 string                `json:"title,omitempty"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity       `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent   IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultCachedSticker struct {
+	Id                  string                `json:"id"`
+	StickerFileId       string                `json:"sticker_file_id"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultCachedDocument struct {
+	Id                  string                `json:"id"`
+	Title               string                `json:"title"`
+	DocumentFileId      string                `json:"document_file_id"`
+	Description         string                `json:"description,omitempty"`
+	Caption             string                `json:"caption,omitempty"`
+	ParseMode           string                `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity       `json:"caption_entities,omitempty"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultCachedVideo struct {
+	Id                    string                `json:"id"`
+	VideoFileId           string                `json:"video_file_id"`
+	Title                 string                `json:"title"`
+	Description           string                `json:"description,omitempty"`
+	Caption               string                `json:"caption,omitempty"`
+	ParseMode             string                `json:"parse_mode,omitempty"`
+	CaptionEntities       []MessageEntity       `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool                  `json:"show_caption_above_media,omitempty"`
+	ReplyMarkup           *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent   IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultCachedVoice struct {
+	Id                  string                `json:"id"`
+	VoiceFileId         string                `json:"voice_file_id"`
+	Title               string                `json:"title"`
+	Caption             string                `json:"caption,omitempty"`
+	ParseMode           string                `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity       `json:"caption_entities,omitempty"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+type InlineQueryResultCachedAudio struct {
+	Id                  string                `json:"id"`
+	AudioFileId         string                `json:"audio_file_id"`
+	Caption             string                `json:"caption,omitempty"`
+	ParseMode           string                `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity       `json:"caption_entities,omitempty"`
+	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+	InputMessageContent IInputMessageContent  `json:"input_message_content,omitempty"`
+}
+
+func (*InlineQueryResultArticle) isInlineQueryResult()        {}
+func (*InlineQueryResultPhoto) isInlineQueryResult()          {}
+func (*InlineQueryResultGif) isInlineQueryResult()            {}
+func (*InlineQueryResultMpeg4Gif) isInlineQueryResult()       {}
+func (*InlineQueryResultVideo) isInlineQueryResult()          {}
+func (*InlineQueryResultAudio) isInlineQueryResult()          {}
+func (*InlineQueryResultVoice) isInlineQueryResult()          {}
+func (*InlineQueryResultDocument) isInlineQueryResult()       {}
+func (*InlineQueryResultLocation) isInlineQueryResult()       {}
+func (*InlineQueryResultVenue) isInlineQueryResult()          {}
+func (*InlineQueryResultContact) isInlineQueryResult()        {}
+func (*InlineQueryResultGame) isInlineQueryResult()           {}
+func (*InlineQueryResultCachedPhoto) isInlineQueryResult()    {}
+func (*InlineQueryResultCachedGif) isInlineQueryResult()      {}
+func (*InlineQueryResultCachedMpeg4Gif) isInlineQueryResult() {}
+func (*InlineQueryResultCachedSticker) isInlineQueryResult()  {}
+func (*InlineQueryResultCachedDocument) isInlineQueryResult() {}
+func (*InlineQueryResultCachedVideo) isInlineQueryResult()    {}
+func (*InlineQueryResultCachedVoice) isInlineQueryResult()    {}
+func (*InlineQueryResultCachedAudio) isInlineQueryResult()    {}
+
+func (r *InlineQueryResultArticle) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultArticle
+	return marshalWithType("article", (*alias)(r))
+}
+
+func (r *InlineQueryResultPhoto) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultPhoto
+	return marshalWithType("photo", (*alias)(r))
+}
+
+func (r *InlineQueryResultGif) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultGif
+	return marshalWithType("gif", (*alias)(r))
+}
+
+func (r *InlineQueryResultMpeg4Gif) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultMpeg4Gif
+	return marshalWithType("mpeg4_gif", (*alias)(r))
+}
+
+func (r *InlineQueryResultVideo) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultVideo
+	return marshalWithType("video", (*alias)(r))
+}
+
+func (r *InlineQueryResultAudio) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultAudio
+	return marshalWithType("audio", (*alias)(r))
+}
+
+func (r *InlineQueryResultVoice) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultVoice
+	return marshalWithType("voice", (*alias)(r))
+}
+
+func (r *InlineQueryResultDocument) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultDocument
+	return marshalWithType("document", (*alias)(r))
+}
+
+func (r *InlineQueryResultLocation) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultLocation
+	return marshalWithType("location", (*alias)(r))
+}
+
+func (r *InlineQueryResultVenue) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultVenue
+	return marshalWithType("venue", (*alias)(r))
+}
+
+func (r *InlineQueryResultContact) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultContact
+	return marshalWithType("contact", (*alias)(r))
+}
+
+func (r *InlineQueryResultGame) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultGame
+	return marshalWithType("game", (*alias)(r))
+}
+
+func (r *InlineQueryResultCachedPhoto) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultCachedPhoto
+	return marshalWithType("photo", (*alias)(r))
+}
+
+func (r *InlineQueryResultCachedGif) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultCachedGif
+	return marshalWithType("gif", (*alias)(r))
+}
+
+func (r *InlineQueryResultCachedMpeg4Gif) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultCachedMpeg4Gif
+	return marshalWithType("mpeg4_gif", (*alias)(r))
+}
+
+func (r *InlineQueryResultCachedSticker) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultCachedSticker
+	return marshalWithType("sticker", (*alias)(r))
+}
+
+func (r *InlineQueryResultCachedDocument) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultCachedDocument
+	return marshalWithType("document", (*alias)(r))
+}
+
+func (r *InlineQueryResultCachedVideo) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultCachedVideo
+	return marshalWithType("video", (*alias)(r))
+}
+
+func (r *InlineQueryResultCachedVoice) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultCachedVoice
+	return marshalWithType("voice", (*alias)(r))
+}
+
+func (r *InlineQueryResultCachedAudio) MarshalJSON() ([]byte, error) {
+	type alias InlineQueryResultCachedAudio
+	return marshalWithType("audio", (*alias)(r))
+}
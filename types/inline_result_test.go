@@ -0,0 +1,52 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInlineQueryResultType(t *testing.T) {
+	tests := []struct {
+		result IInlineQueryResult
+		want   string
+	}{
+		{&InlineQueryResultArticle{Id: "1"}, "article"},
+		{&InlineQueryResultPhoto{Id: "1"}, "photo"},
+		{&InlineQueryResultGif{Id: "1"}, "gif"},
+		{&InlineQueryResultMpeg4Gif{Id: "1"}, "mpeg4_gif"},
+		{&InlineQueryResultVideo{Id: "1"}, "video"},
+		{&InlineQueryResultAudio{Id: "1"}, "audio"},
+		{&InlineQueryResultVoice{Id: "1"}, "voice"},
+		{&InlineQueryResultDocument{Id: "1"}, "document"},
+		{&InlineQueryResultLocation{Id: "1"}, "location"},
+		{&InlineQueryResultVenue{Id: "1"}, "venue"},
+		{&InlineQueryResultContact{Id: "1"}, "contact"},
+		{&InlineQueryResultGame{Id: "1"}, "game"},
+		{&InlineQueryResultCachedPhoto{Id: "1"}, "photo"},
+		{&InlineQueryResultCachedGif{Id: "1"}, "gif"},
+		{&InlineQueryResultCachedMpeg4Gif{Id: "1"}, "mpeg4_gif"},
+		{&InlineQueryResultCachedSticker{Id: "1"}, "sticker"},
+		{&InlineQueryResultCachedDocument{Id: "1"}, "document"},
+		{&InlineQueryResultCachedVideo{Id: "1"}, "video"},
+		{&InlineQueryResultCachedVoice{Id: "1"}, "voice"},
+		{&InlineQueryResultCachedAudio{Id: "1"}, "audio"},
+	}
+	for _, tt := range tests {
+		t.Run(typeName(tt.result), func(t *testing.T) {
+			b, err := json.Marshal(tt.result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			obj := struct {
+				Type string
+				Id   string
+			}{}
+			if err := json.Unmarshal(b, &obj); err != nil {
+				t.Fatal(err)
+			}
+			if obj.Type != tt.want || obj.Id != "1" {
+				t.Errorf("marshaled %s, want type %q and id \"1\"", b, tt.want)
+			}
+		})
+	}
+}
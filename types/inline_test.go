@@ -0,0 +1,34 @@
+package types
+
+import "testing"
+
+func TestInlineQueryPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		offset     string
+		total      int
+		pageSize   int
+		start, end int
+		next       string
+	}{
+		{"first page", "", 25, 10, 0, 10, "10"},
+		{"middle page", "10", 25, 10, 10, 20, "20"},
+		{"last page", "20", 25, 10, 20, 25, ""},
+		{"offset past the end", "30", 25, 10, 25, 25, ""},
+		{"invalid offset", "abc", 25, 10, 0, 10, "10"},
+		{"negative offset", "-5", 25, 10, 0, 10, "10"},
+		{"page size above 50", "", 120, 100, 0, 50, "50"},
+		{"zero page size", "", 5, 0, 0, 1, "1"},
+		{"negative page size", "3", 5, -2, 3, 4, "4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := InlineQuery{Offset: tt.offset}
+			start, end, next := q.Page(tt.total, tt.pageSize)
+			if start != tt.start || end != tt.end || next != tt.next {
+				t.Errorf("Page(%d, %d) = %d, %d, %q, want %d, %d, %q",
+					tt.total, tt.pageSize, start, end, next, tt.start, tt.end, tt.next)
+			}
+		})
+	}
+}
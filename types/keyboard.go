@@ -0,0 +1,134 @@
+package types
+
+import "encoding/json"
+
+// IReplyMarkup is implemented by InlineKeyboardMarkup, ReplyKeyboardMarkup,
+// ReplyKeyboardRemove and ForceReply
+type IReplyMarkup interface {
+	isReplyMarkup()
+}
+
+type WebAppInfo struct {
+	Url string `json:"url"`
+}
+
+type LoginUrl struct {
+	Url                string `json:"url"`
+	ForwardText        string `json:"forward_text,omitempty"`
+	BotUsername        string `json:"bot_username,omitempty"`
+	RequestWriteAccess bool   `json:"request_write_access,omitempty"`
+}
+
+type SwitchInlineQueryChosenChat struct {
+	Query             string `json:"query,omitempty"`
+	AllowUserChats    bool   `json:"allow_user_chats,omitempty"`
+	AllowBotChats     bool   `json:"allow_bot_chats,omitempty"`
+	AllowGroupChats   bool   `json:"allow_group_chats,omitempty"`
+	AllowChannelChats bool   `json:"allow_channel_chats,omitempty"`
+}
+
+type CopyTextButton struct {
+	Text string `json:"text"`
+}
+
+// A placeholder, currently holds no information
+type CallbackGame struct{}
+
+type InlineKeyboardButton struct {
+	Text         string      `json:"text"`
+	Url          string      `json:"url,omitempty"`
+	CallbackData string      `json:"callback_data,omitempty"`
+	WebApp       *WebAppInfo `json:"web_app,omitempty"`
+	LoginUrl     *LoginUrl   `json:"login_url,omitempty"`
+	// Pointers, because an empty query is a valid value
+	SwitchInlineQuery            *string                      `json:"switch_inline_query,omitempty"`
+	SwitchInlineQueryCurrentChat *string                      `json:"switch_inline_query_current_chat,omitempty"`
+	SwitchInlineQueryChosenChat  *SwitchInlineQueryChosenChat `json:"switch_inline_query_chosen_chat,omitempty"`
+	CopyText                     *CopyTextButton              `json:"copy_text,omitempty"`
+	CallbackGame                 *CallbackGame                `json:"callback_game,omitempty"`
+	Pay                          bool                         `json:"pay,omitempty"`
+}
+
+type InlineKeyboardMarkup struct {
+	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
+}
+
+type KeyboardButtonRequestUsers struct {
+	RequestId       int   `json:"request_id"`
+	UserIsBot       *bool `json:"user_is_bot,omitempty"`
+	UserIsPremium   *bool `json:"user_is_premium,omitempty"`
+	MaxQuantity     int   `json:"max_quantity,omitempty"`
+	RequestName     bool  `json:"request_name,omitempty"`
+	RequestUsername bool  `json:"request_username,omitempty"`
+	RequestPhoto    bool  `json:"request_photo,omitempty"`
+}
+
+type KeyboardButtonRequestChat struct {
+	RequestId               int                      `json:"request_id"`
+	ChatIsChannel           bool                     `json:"chat_is_channel"`
+	ChatIsForum             *bool                    `json:"chat_is_forum,omitempty"`
+	ChatHasUsername         *bool                    `json:"chat_has_username,omitempty"`
+	ChatIsCreated           bool                     `json:"chat_is_created,omitempty"`
+	UserAdministratorRights *ChatAdministratorRights `json:"user_administrator_rights,omitempty"`
+	BotAdministratorRights  *ChatAdministratorRights `json:"bot_administrator_rights,omitempty"`
+	BotIsMember             bool                     `json:"bot_is_member,omitempty"`
+	RequestTitle            bool                     `json:"request_title,omitempty"`
+	RequestUsername         bool                     `json:"request_username,omitempty"`
+	RequestPhoto            bool                     `json:"request_photo,omitempty"`
+}
+
+// Type is "quiz", "regular" or empty to allow any poll type
+type KeyboardButtonPollType struct {
+	Type string `json:"type,omitempty"`
+}
+
+type KeyboardButton struct {
+	Text            string                      `json:"text"`
+	RequestUsers    *KeyboardButtonRequestUsers `json:"request_users,omitempty"`
+	RequestChat     *KeyboardButtonRequestChat  `json:"request_chat,omitempty"`
+	RequestContact  bool                        `json:"request_contact,omitempty"`
+	RequestLocation bool                        `json:"request_location,omitempty"`
+	RequestPoll     *KeyboardButtonPollType     `json:"request_poll,omitempty"`
+	WebApp          *WebAppInfo                 `json:"web_app,omitempty"`
+}
+
+type ReplyKeyboardMarkup struct {
+	Keyboard              [][]KeyboardButton `json:"keyboard"`
+	IsPersistent          bool               `json:"is_persistent,omitempty"`
+	ResizeKeyboard        bool               `json:"resize_keyboard,omitempty"`
+	OneTimeKeyboard       bool               `json:"one_time_keyboard,omitempty"`
+	InputFieldPlaceholder string             `json:"input_field_placeholder,omitempty"`
+	Selective             bool               `json:"selective,omitempty"`
+}
+
+type ReplyKeyboardRemove struct {
+	Selective bool `json:"selective,omitempty"`
+}
+
+type ForceReply struct {
+	InputFieldPlaceholder string `json:"input_field_placeholder,omitempty"`
+	Selective             bool   `json:"selective,omitempty"`
+}
+
+func (*InlineKeyboardMarkup) isReplyMarkup() {}
+func (*ReplyKeyboardMarkup) isReplyMarkup()  {}
+func (*ReplyKeyboardRemove) isReplyMarkup()  {}
+func (*ForceReply) isReplyMarkup()           {}
+
+// remove_keyboard must always be true
+func (r *ReplyKeyboardRemove) MarshalJSON() ([]byte, error) {
+	type alias ReplyKeyboardRemove
+	return json.Marshal(struct {
+		RemoveKeyboard bool `json:"remove_keyboard"`
+		*alias
+	}{true, (*alias)(r)})
+}
+
+// force_reply must always be true
+func (f *ForceReply) MarshalJSON() ([]byte, error) {
+	type alias ForceReply
+	return json.Marshal(struct {
+		ForceReply bool `json:"force_reply"`
+		*alias
+	}{true, (*alias)(f)})
+}
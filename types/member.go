@@ -0,0 +1,201 @@
+package types
+
+import "encoding/json"
+
+// IChatMember is implemented by ChatMemberOwner, ChatMemberAdministrator,
+// ChatMemberMember, ChatMemberRestricted, ChatMemberLeft, ChatMemberBanned
+// and ChatMemberUnknown
+type IChatMember interface {
+	MemberStatus() string
+	MemberUser() User
+	// IsAdmin reports whether the member is the owner or an administrator
+	IsAdmin() bool
+	// CanRestrict reports whether the member can ban, unban and restrict
+	// other members
+	CanRestrict() bool
+}
+
+type ChatMemberOwner struct {
+	User        User   `json:"user"`
+	IsAnonymous bool   `json:"is_anonymous"`
+	CustomTitle string `json:"custom_title,omitempty"`
+}
+
+type ChatMemberAdministrator struct {
+	User        User `json:"user"`
+	CanBeEdited bool `json:"can_be_edited"`
+	ChatAdministratorRights
+	CustomTitle string `json:"custom_title,omitempty"`
+}
+
+type ChatMemberMember struct {
+	User User `json:"user"`
+	// Date when the user's subscription will expire
+	UntilDate int64 `json:"until_date,omitempty"`
+}
+
+type ChatMemberRestricted struct {
+	User     User `json:"user"`
+	IsMember bool `json:"is_member"`
+	ChatPermissions
+	// 0 if the user is restricted forever
+	UntilDate int64 `json:"until_date"`
+}
+
+type ChatMemberLeft struct {
+	User User `json:"user"`
+}
+
+type ChatMemberBanned struct {
+	User User `json:"user"`
+	// 0 if the user is banned forever
+	UntilDate int64 `json:"until_date"`
+}
+
+// ChatMemberUnknown is a member whose status has no type here. It's
+// neither an admin nor able to restrict anyone, and Raw keeps the original
+// object so it's encoded back unchanged.
+type ChatMemberUnknown struct {
+	Status string          `json:"status"`
+	User   User            `json:"user"`
+	Raw    json.RawMessage `json:"-"`
+}
+
+func (m *ChatMemberOwner) MemberStatus() string         { return "creator" }
+func (m *ChatMemberAdministrator) MemberStatus() string { return "administrator" }
+func (m *ChatMemberMember) MemberStatus() string        { return "member" }
+func (m *ChatMemberRestricted) MemberStatus() string    { return "restricted" }
+func (m *ChatMemberLeft) MemberStatus() string          { return "left" }
+func (m *ChatMemberBanned) MemberStatus() string        { return "kicked" }
+func (m *ChatMemberUnknown) MemberStatus() string       { return m.Status }
+
+func (m *ChatMemberOwner) MemberUser() User         { return m.User }
+func (m *ChatMemberAdministrator) MemberUser() User { return m.User }
+func (m *ChatMemberMember) MemberUser() User        { return m.User }
+func (m *ChatMemberRestricted) MemberUser() User    { return m.User }
+func (m *ChatMemberLeft) MemberUser() User          { return m.User }
+func (m *ChatMemberBanned) MemberUser() User        { return m.User }
+func (m *ChatMemberUnknown) MemberUser() User       { return m.User }
+
+func (m *ChatMemberOwner) IsAdmin() bool         { return true }
+func (m *ChatMemberAdministrator) IsAdmin() bool { return true }
+func (m *ChatMemberMember) IsAdmin() bool        { return false }
+func (m *ChatMemberRestricted) IsAdmin() bool    { return false }
+func (m *ChatMemberLeft) IsAdmin() bool          { return false }
+func (m *ChatMemberBanned) IsAdmin() bool        { return false }
+func (m *ChatMemberUnknown) IsAdmin() bool       { return false }
+
+func (m *ChatMemberOwner) CanRestrict() bool         { return true }
+func (m *ChatMemberAdministrator) CanRestrict() bool { return m.CanRestrictMembers }
+func (m *ChatMemberMember) CanRestrict() bool        { return false }
+func (m *ChatMemberRestricted) CanRestrict() bool    { return false }
+func (m *ChatMemberLeft) CanRestrict() bool          { return false }
+func (m *ChatMemberBanned) CanRestrict() bool        { return false }
+func (m *ChatMemberUnknown) CanRestrict() bool       { return false }
+
+func (m *ChatMemberOwner) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberOwner
+	return marshalWithKey("status", m.MemberStatus(), (*alias)(m))
+}
+
+func (m *ChatMemberAdministrator) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberAdministrator
+	return marshalWithKey("status", m.MemberStatus(), (*alias)(m))
+}
+
+func (m *ChatMemberMember) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberMember
+	return marshalWithKey("status", m.MemberStatus(), (*alias)(m))
+}
+
+func (m *ChatMemberRestricted) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberRestricted
+	return marshalWithKey("status", m.MemberStatus(), (*alias)(m))
+}
+
+func (m *ChatMemberLeft) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberLeft
+	return marshalWithKey("status", m.MemberStatus(), (*alias)(m))
+}
+
+func (m *ChatMemberBanned) MarshalJSON() ([]byte, error) {
+	type alias ChatMemberBanned
+	return marshalWithKey("status", m.MemberStatus(), (*alias)(m))
+}
+
+func (m *ChatMemberUnknown) MarshalJSON() ([]byte, error) {
+	if len(m.Raw) > 0 {
+		return m.Raw, nil
+	}
+	type alias ChatMemberUnknown
+	return json.Marshal((*alias)(m))
+}
+
+var chatMemberStatuses = map[string]func() IChatMember{
+	"creator":       func() IChatMember { return &ChatMemberOwner{} },
+	"administrator": func() IChatMember { return &ChatMemberAdministrator{} },
+	"member":        func() IChatMember { return &ChatMemberMember{} },
+	"restricted":    func() IChatMember { return &ChatMemberRestricted{} },
+	"left":          func() IChatMember { return &ChatMemberLeft{} },
+	"kicked":        func() IChatMember { return &ChatMemberBanned{} },
+}
+
+// UnmarshalChatMember decodes a ChatMember into its concrete type based on
+// the "status" field. Unknown statuses are decoded into ChatMemberUnknown.
+func UnmarshalChatMember(data []byte) (IChatMember, error) {
+	return unmarshalWithKey(data, "status", chatMemberStatuses, func(raw json.RawMessage) IChatMember {
+		return &ChatMemberUnknown{Raw: raw}
+	})
+}
+
+type ChatInviteLink struct {
+	InviteLink              string `json:"invite_link"`
+	Creator                 User   `json:"creator"`
+	CreatesJoinRequest      bool   `json:"creates_join_request"`
+	IsPrimary               bool   `json:"is_primary"`
+	IsRevoked               bool   `json:"is_revoked"`
+	Name                    string `json:"name,omitempty"`
+	ExpireDate              int64  `json:"expire_date,omitempty"`
+	MemberLimit             int    `json:"member_limit,omitempty"`
+	PendingJoinRequestCount int    `json:"pending_join_request_count,omitempty"`
+	SubscriptionPeriod      int    `json:"subscription_period,omitempty"`
+	SubscriptionPrice       int    `json:"subscription_price,omitempty"`
+}
+
+type ChatMemberUpdated struct {
+	Chat                    Chat            `json:"chat"`
+	From                    User            `json:"from"`
+	Date                    int64           `json:"date"`
+	OldChatMember           IChatMember     `json:"old_chat_member"`
+	NewChatMember           IChatMember     `json:"new_chat_member"`
+	InviteLink              *ChatInviteLink `json:"invite_link,omitempty"`
+	ViaJoinRequest          bool            `json:"via_join_request,omitempty"`
+	ViaChatFolderInviteLink bool            `json:"via_chat_folder_invite_link,omitempty"`
+}
+
+func (u *ChatMemberUpdated) UnmarshalJSON(data []byte) error {
+	type alias ChatMemberUpdated
+	aux := struct {
+		*alias
+		OldChatMember json.RawMessage `json:"old_chat_member"`
+		NewChatMember json.RawMessage `json:"new_chat_member"`
+	}{alias: (*alias)(u)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if u.OldChatMember, err = UnmarshalChatMember(aux.OldChatMember); err != nil {
+		return err
+	}
+	u.NewChatMember, err = UnmarshalChatMember(aux.NewChatMember)
+	return err
+}
+
+type ChatJoinRequest struct {
+	Chat       Chat            `json:"chat"`
+	From       User            `json:"from"`
+	UserChatId int             `json:"user_chat_id"`
+	Date       int64           `json:"date"`
+	Bio        string          `json:"bio,omitempty"`
+	InviteLink *ChatInviteLink `json:"invite_link,omitempty"`
+}
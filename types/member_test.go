@@ -0,0 +1,42 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChatMemberPermissions(t *testing.T) {
+	tests := []struct {
+		member      IChatMember
+		isAdmin     bool
+		canRestrict bool
+	}{
+		{&ChatMemberOwner{}, true, true},
+		{&ChatMemberAdministrator{ChatAdministratorRights: ChatAdministratorRights{CanRestrictMembers: true}}, true, true},
+		{&ChatMemberAdministrator{}, true, false},
+		{&ChatMemberMember{}, false, false},
+		{&ChatMemberRestricted{}, false, false},
+		{&ChatMemberLeft{}, false, false},
+		{&ChatMemberBanned{}, false, false},
+		{&ChatMemberUnknown{Status: "new_status"}, false, false},
+	}
+	for _, tt := range tests {
+		if tt.member.IsAdmin() != tt.isAdmin || tt.member.CanRestrict() != tt.canRestrict {
+			t.Errorf("%s: IsAdmin() = %v, CanRestrict() = %v, want %v, %v",
+				typeName(tt.member), tt.member.IsAdmin(), tt.member.CanRestrict(), tt.isAdmin, tt.canRestrict)
+		}
+	}
+}
+
+func TestChatMemberUpdatedWithUnknownStatus(t *testing.T) {
+	data := `{"chat":{"id":-1,"type":"group"},"from":{"id":1},"date":1,
+		"old_chat_member":{"status":"new_status","user":{"id":2}},
+		"new_chat_member":{"status":"member","user":{"id":2}}}`
+	updated := ChatMemberUpdated{}
+	if err := json.Unmarshal([]byte(data), &updated); err != nil {
+		t.Fatal(err)
+	}
+	if status := updated.OldChatMember.MemberStatus(); status != "new_status" {
+		t.Errorf("OldChatMember status = %q", status)
+	}
+}
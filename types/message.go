@@ -1,42 +1,271 @@
 package types
 
 import (
+	"encoding/json"
 	"errors"
 	"net/url"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf16"
 )
 
 type MessageEntity struct {
-	Type   string `json:"type"`
-	Offset int    `json:"offset"`
-	Length int    `json:"length"`
-	Url    string `json:"url,omitempty"`
-	User   *User  `json:"user,omitempty"`
+	Type          string `json:"type"`
+	Offset        int    `json:"offset"`
+	Length        int    `json:"length"`
+	Url           string `json:"url,omitempty"`
+	User          *User  `json:"user,omitempty"`
+	Language      string `json:"language,omitempty"`
+	CustomEmojiId string `json:"custom_emoji_id,omitempty"`
 }
 
 type Message struct {
-	Id       int             `json:"message_id"`
-	Date     int64           `json:"date"`
-	Chat     *Chat           `json:"chat"`
-	From     *User           `json:"from,omitempty"`
-	Document *Document       `json:"document,omitempty"`
-	Text     string          `json:"text,omitempty"`
+	Id                   int                 `json:"message_id"`
+	MessageThreadId      int                 `json:"message_thread_id,omitempty"`
+	From                 *User               `json:"from,omitempty"`
+	SenderChat           *Chat               `json:"sender_chat,omitempty"`
+	SenderBoostCount     int                 `json:"sender_boost_count,omitempty"`
+	SenderBusinessBot    *User               `json:"sender_business_bot,omitempty"`
+	Date                 int64               `json:"date"`
+	BusinessConnectionId string              `json:"business_connection_id,omitempty"`
+	Chat                 *Chat               `json:"chat"`
+	ForwardOrigin        IMessageOrigin      `json:"forward_origin,omitempty"`
+	IsTopicMessage       bool                `json:"is_topic_message,omitempty"`
+	IsAutomaticForward   bool                `json:"is_automatic_forward,omitempty"`
+	ReplyTo              *Message            `json:"reply_to_message,omitempty"`
+	ExternalReply        *ExternalReplyInfo  `json:"external_reply,omitempty"`
+	Quote                *TextQuote          `json:"quote,omitempty"`
+	ReplyToStory         *Story              `json:"reply_to_story,omitempty"`
+	ViaBot               *User               `json:"via_bot,omitempty"`
+	EditDate             uint                `json:"edit_date,omitempty"`
+	HasProtectedContent  bool                `json:"has_protected_content,omitempty"`
+	IsFromOffline        bool                `json:"is_from_offline,omitempty"`
+	IsPaidPost           bool                `json:"is_paid_post,omitempty"`
+	MediaGroupId         string              `json:"media_group_id,omitempty"`
+	AuthorSignature      string              `json:"author_signature,omitempty"`
+	PaidStarCount        int                 `json:"paid_star_count,omitempty"`
+	Text                 string              `json:"text,omitempty"`
+	Entities             []MessageEntity     `json:"entities,omitempty"`
+	LinkPreviewOptions   *LinkPreviewOptions `json:"link_preview_options,omitempty"`
+	EffectId             string              `json:"effect_id,omitempty"`
+
+	ReplyToChecklistTaskId int                `json:"reply_to_checklist_task_id,omitempty"`
+	SuggestedPostInfo      *SuggestedPostInfo `json:"suggested_post_info,omitempty"`
+
+	Animation             *Animation      `json:"animation,omitempty"`
+	Audio                 *Audio          `json:"audio,omitempty"`
+	Document              *Document       `json:"document,omitempty"`
+	PaidMedia             *PaidMediaInfo  `json:"paid_media,omitempty"`
+	Photo                 []PhotoSize     `json:"photo,omitempty"`
+	Sticker               *Sticker        `json:"sticker,omitempty"`
+	Story                 *Story          `json:"story,omitempty"`
+	Video                 *Video          `json:"video,omitempty"`
+	VideoNote             *VideoNote      `json:"video_note,omitempty"`
+	Voice                 *Voice          `json:"voice,omitempty"`
+	Caption               string          `json:"caption,omitempty"`
+	CaptionEntities       []MessageEntity `json:"caption_entities,omitempty"`
+	ShowCaptionAboveMedia bool            `json:"show_caption_above_media,omitempty"`
+	HasMediaSpoiler       bool            `json:"has_media_spoiler,omitempty"`
+	Contact               *Contact        `json:"contact,omitempty"`
+	Dice                  *Dice           `json:"dice,omitempty"`
+	Game                  *Game           `json:"game,omitempty"`
+	Poll                  *Poll           `json:"poll,omitempty"`
+	Venue                 *Venue          `json:"venue,omitempty"`
+	Location              *Location       `json:"location,omitempty"`
+
+	Checklist       *Checklist       `json:"checklist,omitempty"`
+	Giveaway        *Giveaway        `json:"giveaway,omitempty"`
+	GiveawayWinners *GiveawayWinners `json:"giveaway_winners,omitempty"`
+
+	// Service messages
+	NewChatMembers                []User                         `json:"new_chat_members,omitempty"`
+	LeftChatMember                *User                          `json:"left_chat_member,omitempty"`
+	NewChatTitle                  string                         `json:"new_chat_title,omitempty"`
+	NewChatPhoto                  []PhotoSize                    `json:"new_chat_photo,omitempty"`
+	DeleteChatPhoto               bool                           `json:"delete_chat_photo,omitempty"`
+	GroupChatCreated              bool                           `json:"group_chat_created,omitempty"`
+	SupergroupChatCreated         bool                           `json:"supergroup_chat_created,omitempty"`
+	ChannelChatCreated            bool                           `json:"channel_chat_created,omitempty"`
+	MessageAutoDeleteTimerChanged *MessageAutoDeleteTimerChanged `json:"message_auto_delete_timer_changed,omitempty"`
+	MigrateToChatId               int                            `json:"migrate_to_chat_id,omitempty"`
+	MigrateFromChatId             int                            `json:"migrate_from_chat_id,omitempty"`
+	PinnedMessage                 *MaybeInaccessibleMessage      `json:"pinned_message,omitempty"`
+	Invoice                       *Invoice                       `json:"invoice,omitempty"`
+	SuccessfulPayment             *SuccessfulPayment             `json:"successful_payment,omitempty"`
+	RefundedPayment               *RefundedPayment               `json:"refunded_payment,omitempty"`
+	UsersShared                   *UsersShared                   `json:"users_shared,omitempty"`
+	ChatShared                    *ChatShared                    `json:"chat_shared,omitempty"`
+	ConnectedWebsite              string                         `json:"connected_website,omitempty"`
+	WriteAccessAllowed            *WriteAccessAllowed            `json:"write_access_allowed,omitempty"`
+	ProximityAlertTriggered       *ProximityAlertTriggered       `json:"proximity_alert_triggered,omitempty"`
+	BoostAdded                    *ChatBoostAdded                `json:"boost_added,omitempty"`
+	ForumTopicCreated             *ForumTopicCreated             `json:"forum_topic_created,omitempty"`
+	ForumTopicEdited              *ForumTopicEdited              `json:"forum_topic_edited,omitempty"`
+	ForumTopicClosed              *ForumTopicClosed              `json:"forum_topic_closed,omitempty"`
+	ForumTopicReopened            *ForumTopicReopened            `json:"forum_topic_reopened,omitempty"`
+	GeneralForumTopicHidden       *GeneralForumTopicHidden       `json:"general_forum_topic_hidden,omitempty"`
+	GeneralForumTopicUnhidden     *GeneralForumTopicUnhidden     `json:"general_forum_topic_unhidden,omitempty"`
+	VideoChatScheduled            *VideoChatScheduled            `json:"video_chat_scheduled,omitempty"`
+	VideoChatStarted              *VideoChatStarted              `json:"video_chat_started,omitempty"`
+	VideoChatEnded                *VideoChatEnded                `json:"video_chat_ended,omitempty"`
+	VideoChatParticipantsInvited  *VideoChatParticipantsInvited  `json:"video_chat_participants_invited,omitempty"`
+	WebAppData                    *WebAppData                    `json:"web_app_data,omitempty"`
+	PassportData                  *PassportData                  `json:"passport_data,omitempty"`
+	Gift                          *GiftInfo                      `json:"gift,omitempty"`
+	UniqueGift                    *UniqueGiftInfo                `json:"unique_gift,omitempty"`
+	GiveawayCreated               *GiveawayCreated               `json:"giveaway_created,omitempty"`
+	GiveawayCompleted             *GiveawayCompleted             `json:"giveaway_completed,omitempty"`
+	ChecklistTasksDone            *ChecklistTasksDone            `json:"checklist_tasks_done,omitempty"`
+	ChecklistTasksAdded           *ChecklistTasksAdded           `json:"checklist_tasks_added,omitempty"`
+	SuggestedPostApproved         *SuggestedPostApproved         `json:"suggested_post_approved,omitempty"`
+	SuggestedPostApprovalFailed   *SuggestedPostApprovalFailed   `json:"suggested_post_approval_failed,omitempty"`
+	SuggestedPostDeclined         *SuggestedPostDeclined         `json:"suggested_post_declined,omitempty"`
+	SuggestedPostPaid             *SuggestedPostPaid             `json:"suggested_post_paid,omitempty"`
+	SuggestedPostRefunded         *SuggestedPostRefunded         `json:"suggested_post_refunded,omitempty"`
+
+	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type alias Message
+	aux := struct {
+		*alias
+		ForwardOrigin json.RawMessage `json:"forward_origin,omitempty"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	m.ForwardOrigin = nil
+	if len(aux.ForwardOrigin) > 0 {
+		origin, err := UnmarshalMessageOrigin(aux.ForwardOrigin)
+		if err != nil {
+			return err
+		}
+		m.ForwardOrigin = origin
+	}
+	return nil
+}
+
+// TextQuote is the quoted part of a message replied by another message
+type TextQuote struct {
+	Text     string          `json:"text"`
 	Entities []MessageEntity `json:"entities,omitempty"`
-	ReplyTo  *Message        `json:"reply_to_message,omitempty"`
-	EditDate uint            `json:"edit_date,omitempty"`
+	Position int             `json:"position"`
+	IsManual bool            `json:"is_manual,omitempty"`
+}
+
+// ExternalReplyInfo describes a replied message that is in another chat
+// or forum topic
+type ExternalReplyInfo struct {
+	Origin             IMessageOrigin      `json:"origin"`
+	Chat               *Chat               `json:"chat,omitempty"`
+	MessageId          int                 `json:"message_id,omitempty"`
+	LinkPreviewOptions *LinkPreviewOptions `json:"link_preview_options,omitempty"`
+	Animation          *Animation          `json:"animation,omitempty"`
+	Audio              *Audio              `json:"audio,omitempty"`
+	Document           *Document           `json:"document,omitempty"`
+	PaidMedia          *PaidMediaInfo      `json:"paid_media,omitempty"`
+	Photo              []PhotoSize         `json:"photo,omitempty"`
+	Sticker            *Sticker            `json:"sticker,omitempty"`
+	Story              *Story              `json:"story,omitempty"`
+	Video              *Video              `json:"video,omitempty"`
+	VideoNote          *VideoNote          `json:"video_note,omitempty"`
+	Voice              *Voice              `json:"voice,omitempty"`
+	HasMediaSpoiler    bool                `json:"has_media_spoiler,omitempty"`
+	Contact            *Contact            `json:"contact,omitempty"`
+	Dice               *Dice               `json:"dice,omitempty"`
+	Game               *Game               `json:"game,omitempty"`
+	Checklist          *Checklist          `json:"checklist,omitempty"`
+	Giveaway           *Giveaway           `json:"giveaway,omitempty"`
+	GiveawayWinners    *GiveawayWinners    `json:"giveaway_winners,omitempty"`
+	Invoice            *Invoice            `json:"invoice,omitempty"`
+	Location           *Location           `json:"location,omitempty"`
+	Poll               *Poll               `json:"poll,omitempty"`
+	Venue              *Venue              `json:"venue,omitempty"`
+}
+
+func (r *ExternalReplyInfo) UnmarshalJSON(data []byte) error {
+	type alias ExternalReplyInfo
+	aux := struct {
+		*alias
+		Origin json.RawMessage `json:"origin"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	origin, err := UnmarshalMessageOrigin(aux.Origin)
+	if err != nil {
+		return err
+	}
+	r.Origin = origin
+	return nil
 }
 
 type MessageId int
 
+// InaccessibleMessage describes a message that was deleted or is
+// otherwise inaccessible to the bot. Its date is always 0.
+type InaccessibleMessage struct {
+	Chat      Chat  `json:"chat"`
+	MessageId int   `json:"message_id"`
+	Date      int64 `json:"date"`
+}
+
+// MaybeInaccessibleMessage holds either an accessible Message or an
+// InaccessibleMessage. Exactly one of the fields is set.
 type MaybeInaccessibleMessage struct {
-	Type          string
-	Offset        int
-	Length        int
-	Url           string `json:"url,omitempty"`
-	User          *User  `json:"user,omitempty"`
-	Language      string `json:"language,omitempty"`
-	CustomEmojiId string `json:"custom_emoji_id,omitempty"`
+	Message      *Message
+	Inaccessible *InaccessibleMessage
+}
+
+func (m *MaybeInaccessibleMessage) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Date int64 `json:"date"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	if probe.Date == 0 {
+		m.Message = nil
+		m.Inaccessible = &InaccessibleMessage{}
+		return json.Unmarshal(data, m.Inaccessible)
+	}
+	m.Inaccessible = nil
+	m.Message = &Message{}
+	return json.Unmarshal(data, m.Message)
+}
+
+func (m *MaybeInaccessibleMessage) MarshalJSON() ([]byte, error) {
+	if m.Message != nil {
+		return json.Marshal(m.Message)
+	}
+	return json.Marshal(m.Inaccessible)
+}
+
+func (m *MaybeInaccessibleMessage) IsAccessible() bool {
+	return m.Message != nil
+}
+
+func (m *MaybeInaccessibleMessage) MessageId() int {
+	if m.Message != nil {
+		return m.Message.Id
+	}
+	if m.Inaccessible != nil {
+		return m.Inaccessible.MessageId
+	}
+	return 0
+}
+
+// GetChat returns the chat of the message, accessible or not
+func (m *MaybeInaccessibleMessage) GetChat() *Chat {
+	if m.Message != nil {
+		return m.Message.Chat
+	}
+	if m.Inaccessible != nil {
+		return &m.Inaccessible.Chat
+	}
+	return nil
 }
 
 func (e *MessageEntity) IsCommand() bool {
@@ -54,34 +283,119 @@ func (m *Message) Time() time.Time {
 	return time.Unix(m.Date, 0)
 }
 
-func (m *Message) IsCommand() bool {
-	if len(m.Entities) > 0 {
-		return m.Entities[0].IsCommand()
+// textAndEntities returns the text of the message and its entities,
+// or the caption and caption entities for media messages.
+func (m *Message) textAndEntities() (string, []MessageEntity) {
+	if m.Text == "" && m.Caption != "" {
+		return m.Caption, m.CaptionEntities
 	}
-	txt := m.Text
-	if idx := strings.IndexByte(txt, '/'); idx == 0 {
-		return (len(txt) > 1)
+	return m.Text, m.Entities
+}
+
+// EntityText returns the part of the text (or caption) that the entity
+// refers to. Entity offsets are counted in UTF-16 code units.
+func (m *Message) EntityText(e MessageEntity) string {
+	text, _ := m.textAndEntities()
+	return UTF16Slice(text, e.Offset, e.Offset+e.Length)
+}
+
+// EntitiesOfType returns entities of the text (or caption) with the
+// given type
+func (m *Message) EntitiesOfType(entityType string) []MessageEntity {
+	_, entities := m.textAndEntities()
+	result := []MessageEntity{}
+	for _, e := range entities {
+		if e.Type == entityType {
+			result = append(result, e)
+		}
 	}
-	return false
+	return result
 }
 
+func (m *Message) IsCommand() bool {
+	_, _, ok := m.parseCommand()
+	return ok
+}
+
+// Command returns the command without the leading slash and the bot
+// username, e.g. "start" for "/start@my_bot arg". Use CommandFor to
+// reject commands sent to other bots.
 func (m *Message) Command() (string, bool) {
-	if !m.IsCommand() {
+	command, _, ok := m.parseCommand()
+	return command, ok
+}
+
+// CommandFor is like Command, but reports false for commands explicitly
+// addressed to a bot other than botUsername (e.g. "/start@OtherBot").
+func (m *Message) CommandFor(botUsername string) (string, bool) {
+	command, username, ok := m.parseCommand()
+	if !ok || (username != "" && !strings.EqualFold(username, botUsername)) {
 		return "", false
 	}
-	var command string
-	if len(m.Entities) > 0 {
-		e := m.Entities[0]
-		command = m.Text[1:e.Length]
-		if idx := strings.IndexByte(command, '@'); idx != -1 {
-			command = command[:idx]
+	return command, true
+}
+
+// CommandArgs returns the text after the command, with surrounding
+// whitespace trimmed
+func (m *Message) CommandArgs() string {
+	text, _ := m.textAndEntities()
+	if e, ok := m.commandEntity(); ok {
+		return strings.TrimSpace(UTF16Slice(text, e.Offset+e.Length, UTF16Len(text)))
+	}
+	if !m.IsCommand() {
+		return ""
+	}
+	if idx := strings.IndexFunc(text, unicode.IsSpace); idx != -1 {
+		return strings.TrimSpace(text[idx:])
+	}
+	return ""
+}
+
+func (m *Message) commandEntity() (MessageEntity, bool) {
+	_, entities := m.textAndEntities()
+	for _, e := range entities {
+		if e.IsCommand() {
+			return e, true
 		}
-		goto ret
 	}
-	command = m.Text[1:]
-	if idx := strings.IndexByte(command, ' '); idx != -1 {
-		command = command[:idx]
+	return MessageEntity{}, false
+}
+
+// parseCommand splits "/command@username" into its parts. Messages
+// without entities fall back to parsing the raw text.
+func (m *Message) parseCommand() (command, username string, ok bool) {
+	text, entities := m.textAndEntities()
+	var raw string
+	if e, found := m.commandEntity(); found {
+		raw = m.EntityText(e)
+	} else if len(entities) == 0 && strings.HasPrefix(text, "/") {
+		raw = text
+		if idx := strings.IndexFunc(raw, unicode.IsSpace); idx != -1 {
+			raw = raw[:idx]
+		}
 	}
-ret:
-	return command, true
+	if len(raw) < 2 {
+		return "", "", false
+	}
+	command, username, _ = strings.Cut(raw[1:], "@")
+	return command, username, command != ""
+}
+
+// UTF16Len returns the length of s in UTF-16 code units, the unit of
+// MessageEntity offsets and lengths
+func UTF16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// UTF16Slice returns s[start:end] where the bounds are in UTF-16 code
+// units. Out of range bounds are clamped.
+func UTF16Slice(s string, start, end int) string {
+	units := utf16.Encode([]rune(s))
+	start = max(0, min(start, len(units)))
+	end = max(start, min(end, len(units)))
+	return string(utf16.Decode(units[start:end]))
 }
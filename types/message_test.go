@@ -0,0 +1,137 @@
+package types
+
+import "testing"
+
+func command(offset, length int) MessageEntity {
+	return MessageEntity{Type: "bot_command", Offset: offset, Length: length}
+}
+
+func TestEntityText(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    Message
+		entity MessageEntity
+		want   string
+	}{
+		{"ascii", Message{Text: "hello world"}, MessageEntity{Offset: 6, Length: 5}, "world"},
+		{"cyrillic", Message{Text: "Привет мир"}, MessageEntity{Offset: 7, Length: 3}, "мир"},
+		{"emoji before entity", Message{Text: "😀😀 bold"}, MessageEntity{Offset: 5, Length: 4}, "bold"},
+		{"emoji inside entity", Message{Text: "a 👍🏽 b"}, MessageEntity{Offset: 2, Length: 4}, "👍🏽"},
+		{"surrogate pair before cyrillic", Message{Text: "𝄞 Ёжик"}, MessageEntity{Offset: 3, Length: 4}, "Ёжик"},
+		{"persian", Message{Text: "سلام دنیا"}, MessageEntity{Offset: 5, Length: 4}, "دنیا"},
+		{"caption", Message{Caption: "😀 caption"}, MessageEntity{Offset: 3, Length: 7}, "caption"},
+		{"out of range", Message{Text: "short"}, MessageEntity{Offset: 3, Length: 10}, "rt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.EntityText(tt.entity); got != tt.want {
+				t.Errorf("EntityText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     Message
+		bot     string
+		command string
+		ok      bool
+		args    string
+	}{
+		{
+			name:    "plain",
+			msg:     Message{Text: "/start", Entities: []MessageEntity{command(0, 6)}},
+			bot:     "my_bot",
+			command: "start", ok: true,
+		},
+		{
+			name:    "with args",
+			msg:     Message{Text: "/echo  hello world ", Entities: []MessageEntity{command(0, 5)}},
+			bot:     "my_bot",
+			command: "echo", ok: true, args: "hello world",
+		},
+		{
+			name:    "addressed to this bot",
+			msg:     Message{Text: "/start@my_bot x", Entities: []MessageEntity{command(0, 13)}},
+			bot:     "my_bot",
+			command: "start", ok: true, args: "x",
+		},
+		{
+			name:    "case insensitive username",
+			msg:     Message{Text: "/start@My_Bot", Entities: []MessageEntity{command(0, 13)}},
+			bot:     "my_bot",
+			command: "start", ok: true,
+		},
+		{
+			name: "addressed to another bot",
+			msg:  Message{Text: "/start@OtherBot", Entities: []MessageEntity{command(0, 15)}},
+			bot:  "my_bot",
+			args: "",
+		},
+		{
+			name:    "cyrillic args",
+			msg:     Message{Text: "/say Привет, мир", Entities: []MessageEntity{command(0, 4)}},
+			bot:     "my_bot",
+			command: "say", ok: true, args: "Привет, мир",
+		},
+		{
+			name:    "emoji args",
+			msg:     Message{Text: "/react 👍🏽 😀", Entities: []MessageEntity{command(0, 6)}},
+			bot:     "my_bot",
+			command: "react", ok: true, args: "👍🏽 😀",
+		},
+		{
+			// only a command at the start of the message counts
+			name: "surrogate pair before command",
+			msg:  Message{Text: "😀 /start now", Entities: []MessageEntity{command(3, 6)}},
+			bot:  "my_bot",
+		},
+		{
+			name:    "caption",
+			msg:     Message{Caption: "/upload ёлка", CaptionEntities: []MessageEntity{command(0, 7)}},
+			bot:     "my_bot",
+			command: "upload", ok: true, args: "ёлка",
+		},
+		{
+			name:    "no entities",
+			msg:     Message{Text: "/start@my_bot a b"},
+			bot:     "my_bot",
+			command: "start", ok: true, args: "a b",
+		},
+		{
+			name: "not a command",
+			msg:  Message{Text: "hello /start", Entities: []MessageEntity{{Type: "bold", Offset: 0, Length: 5}}},
+			bot:  "my_bot",
+		},
+		{
+			name: "lone slash",
+			msg:  Message{Text: "/"},
+			bot:  "my_bot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, ok := tt.msg.CommandFor(tt.bot)
+			if command != tt.command || ok != tt.ok {
+				t.Errorf("CommandFor(%q) = %q, %v, want %q, %v", tt.bot, command, ok, tt.command, tt.ok)
+			}
+			if tt.ok {
+				if got := tt.msg.CommandArgs(); got != tt.args {
+					t.Errorf("CommandArgs() = %q, want %q", got, tt.args)
+				}
+			}
+		})
+	}
+}
+
+func TestCommandIgnoresUsername(t *testing.T) {
+	msg := Message{Text: "/start@OtherBot", Entities: []MessageEntity{command(0, 15)}}
+	if command, ok := msg.Command(); !ok || command != "start" {
+		t.Errorf("Command() = %q, %v, want \"start\", true", command, ok)
+	}
+	if !msg.IsCommand() {
+		t.Error("IsCommand() = false")
+	}
+}
@@ -31,11 +31,66 @@ type LinkPreviewOptions struct {
 }
 
 type SuggestedPostPrice struct {
-	Currency string
-	Amount   int
+	// "XTR" for Telegram Stars or "TON" for toncoins
+	Currency string `json:"currency"`
+	Amount   int    `json:"amount"`
+}
+
+type StarAmount struct {
+	Amount         int `json:"amount"`
+	NanoStarAmount int `json:"nano_star_amount,omitempty"`
+}
+
+type SuggestedPostInfo struct {
+	// One of "pending", "approved" or "declined"
+	State    string              `json:"state"`
+	Price    *SuggestedPostPrice `json:"price,omitempty"`
+	SendDate int64               `json:"send_date,omitempty"`
 }
 
 type PaidMediaPurchased struct {
 	From             User
 	PaidMediaPayload string `json:"paid_media_payload"`
 }
+
+type ResponseParameters struct {
+	MigrateToChatId int `json:"migrate_to_chat_id,omitempty"`
+	RetryAfter      int `json:"retry_after,omitempty"`
+}
+
+type Contact struct {
+	PhoneNumber string `json:"phone_number"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name,omitempty"`
+	UserId      int    `json:"user_id,omitempty"`
+	Vcard       string `json:"vcard,omitempty"`
+}
+
+type Dice struct {
+	Emoji string `json:"emoji"`
+	Value int    `json:"value"`
+}
+
+type Venue struct {
+	Location        Location `json:"location"`
+	Title           string   `json:"title"`
+	Address         string   `json:"address"`
+	FoursquareId    string   `json:"foursquare_id,omitempty"`
+	FoursquareType  string   `json:"foursquare_type,omitempty"`
+	GooglePlaceId   string   `json:"google_place_id,omitempty"`
+	GooglePlaceType string   `json:"google_place_type,omitempty"`
+}
+
+type Game struct {
+	Title        string          `json:"title"`
+	Description  string          `json:"description"`
+	Photo        []PhotoSize     `json:"photo"`
+	Text         string          `json:"text,omitempty"`
+	TextEntities []MessageEntity `json:"text_entities,omitempty"`
+	Animation    *Animation      `json:"animation,omitempty"`
+}
+
+type WebAppData struct {
+	Data       string `json:"data"`
+	ButtonText string `json:"button_text"`
+}
@@ -0,0 +1,92 @@
+package types
+
+import "encoding/json"
+
+// IMessageOrigin is implemented by MessageOriginUser,
+// MessageOriginHiddenUser, MessageOriginChat, MessageOriginChannel and
+// MessageOriginUnknown
+type IMessageOrigin interface {
+	OriginDate() int64
+}
+
+type MessageOriginUser struct {
+	Date       int64 `json:"date"`
+	SenderUser User  `json:"sender_user"`
+}
+
+type MessageOriginHiddenUser struct {
+	Date           int64  `json:"date"`
+	SenderUserName string `json:"sender_user_name"`
+}
+
+type MessageOriginChat struct {
+	Date            int64  `json:"date"`
+	SenderChat      Chat   `json:"sender_chat"`
+	AuthorSignature string `json:"author_signature,omitempty"`
+}
+
+type MessageOriginChannel struct {
+	Date            int64  `json:"date"`
+	Chat            Chat   `json:"chat"`
+	MessageId       int    `json:"message_id"`
+	AuthorSignature string `json:"author_signature,omitempty"`
+}
+
+// MessageOriginUnknown is the origin of a forwarded message sent by a kind
+// of sender without a type here. Only the date is decoded, the rest of the
+// origin is left in Raw.
+type MessageOriginUnknown struct {
+	Type string          `json:"type"`
+	Date int64           `json:"date"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+func (o *MessageOriginUser) OriginDate() int64       { return o.Date }
+func (o *MessageOriginHiddenUser) OriginDate() int64 { return o.Date }
+func (o *MessageOriginChat) OriginDate() int64       { return o.Date }
+func (o *MessageOriginChannel) OriginDate() int64    { return o.Date }
+func (o *MessageOriginUnknown) OriginDate() int64    { return o.Date }
+
+func (o *MessageOriginUser) MarshalJSON() ([]byte, error) {
+	type alias MessageOriginUser
+	return marshalWithType("user", (*alias)(o))
+}
+
+func (o *MessageOriginHiddenUser) MarshalJSON() ([]byte, error) {
+	type alias MessageOriginHiddenUser
+	return marshalWithType("hidden_user", (*alias)(o))
+}
+
+func (o *MessageOriginChat) MarshalJSON() ([]byte, error) {
+	type alias MessageOriginChat
+	return marshalWithType("chat", (*alias)(o))
+}
+
+func (o *MessageOriginChannel) MarshalJSON() ([]byte, error) {
+	type alias MessageOriginChannel
+	return marshalWithType("channel", (*alias)(o))
+}
+
+func (o *MessageOriginUnknown) MarshalJSON() ([]byte, error) {
+	if len(o.Raw) > 0 {
+		return o.Raw, nil
+	}
+	type alias MessageOriginUnknown
+	return json.Marshal((*alias)(o))
+}
+
+var messageOriginTypes = map[string]func() IMessageOrigin{
+	"user":        func() IMessageOrigin { return &MessageOriginUser{} },
+	"hidden_user": func() IMessageOrigin { return &MessageOriginHiddenUser{} },
+	"chat":        func() IMessageOrigin { return &MessageOriginChat{} },
+	"channel":     func() IMessageOrigin { return &MessageOriginChannel{} },
+}
+
+// UnmarshalMessageOrigin decodes a MessageOrigin into its concrete type
+// based on the "type" field. Unknown types are decoded into
+// MessageOriginUnknown, so new Bot API types don't break decoding.
+func UnmarshalMessageOrigin(data []byte) (IMessageOrigin, error) {
+	return unmarshalWithKey(data, "type", messageOriginTypes, func(raw json.RawMessage) IMessageOrigin {
+		return &MessageOriginUnknown{Raw: raw}
+	})
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMessageWithUnknownOrigin(t *testing.T) {
+	data := `{"message_id":1,"date":2,"chat":{"id":1,"type":"private"},"forward_origin":{"type":"new_kind","date":3}}`
+	msg := Message{}
+	if err := json.Unmarshal([]byte(data), &msg); err != nil {
+		t.Fatal(err)
+	}
+	unknown, ok := msg.ForwardOrigin.(*MessageOriginUnknown)
+	if !ok || unknown.Type != "new_kind" || unknown.Date != 3 {
+		t.Fatalf("ForwardOrigin = %#v", msg.ForwardOrigin)
+	}
+
+	encoded, err := json.Marshal(unknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `{"type":"new_kind","date":3}` {
+		t.Errorf("unknown origin encoded as %s", encoded)
+	}
+}
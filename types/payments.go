@@ -32,3 +32,37 @@ type OrderInfo struct {
 	Email           string           `json:"email,omitempty"`
 	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
 }
+
+type LabeledPrice struct {
+	Label  string `json:"label"`
+	Amount int    `json:"amount"`
+}
+
+type Invoice struct {
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	StartParameter string `json:"start_parameter"`
+	Currency       string `json:"currency"`
+	TotalAmount    int    `json:"total_amount"`
+}
+
+type SuccessfulPayment struct {
+	Currency                   string     `json:"currency"`
+	TotalAmount                int        `json:"total_amount"`
+	InvoicePayload             string     `json:"invoice_payload"`
+	SubscriptionExpirationDate int64      `json:"subscription_expiration_date,omitempty"`
+	IsRecurring                bool       `json:"is_recurring,omitempty"`
+	IsFirstRecurring           bool       `json:"is_first_recurring,omitempty"`
+	ShippingOptionId           string     `json:"shipping_option_id,omitempty"`
+	OrderInfo                  *OrderInfo `json:"order_info,omitempty"`
+	TelegramPaymentChargeId    string     `json:"telegram_payment_charge_id"`
+	ProviderPaymentChargeId    string     `json:"provider_payment_charge_id"`
+}
+
+type RefundedPayment struct {
+	Currency                string `json:"currency"`
+	TotalAmount             int    `json:"total_amount"`
+	InvoicePayload          string `json:"invoice_payload"`
+	TelegramPaymentChargeId string `json:"telegram_payment_charge_id"`
+	ProviderPaymentChargeId string `json:"provider_payment_charge_id,omitempty"`
+}
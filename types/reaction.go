@@ -1,39 +1,136 @@
 package types
 
-type IReactionType any
+import "encoding/json"
+
+// IReactionType is implemented by ReactionTypeEmoji,
+// ReactionTypeCustomEmoji, ReactionTypePaid and ReactionTypeUnknown
+type IReactionType interface {
+	isReactionType()
+}
 
 type ReactionTypeEmoji struct {
-	// Type string
 	Emoji string `json:"emoji"`
 }
 
 type ReactionTypeCustomEmoji struct {
-	// Type string
 	CustomEmojiId string `json:"custom_emoji_id"`
 }
 
-type ReactionTypePaid struct {
-	Type string
+type ReactionTypePaid struct{}
+
+// ReactionTypeUnknown is a reaction of an unrecognised type. It marshals
+// back to Raw, so it can be passed to SetMessageReaction as received.
+type ReactionTypeUnknown struct {
+	Type string          `json:"type"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+func (*ReactionTypeEmoji) isReactionType()       {}
+func (*ReactionTypeCustomEmoji) isReactionType() {}
+func (*ReactionTypePaid) isReactionType()        {}
+func (*ReactionTypeUnknown) isReactionType()     {}
+
+func (r *ReactionTypeEmoji) MarshalJSON() ([]byte, error) {
+	type alias ReactionTypeEmoji
+	return marshalWithType("emoji", (*alias)(r))
+}
+
+func (r *ReactionTypeCustomEmoji) MarshalJSON() ([]byte, error) {
+	type alias ReactionTypeCustomEmoji
+	return marshalWithType("custom_emoji", (*alias)(r))
+}
+
+func (r *ReactionTypePaid) MarshalJSON() ([]byte, error) {
+	type alias ReactionTypePaid
+	return marshalWithType("paid", (*alias)(r))
+}
+
+func (r *ReactionTypeUnknown) MarshalJSON() ([]byte, error) {
+	if len(r.Raw) > 0 {
+		return r.Raw, nil
+	}
+	type alias ReactionTypeUnknown
+	return json.Marshal((*alias)(r))
+}
+
+var reactionTypes = map[string]func() IReactionType{
+	"emoji":        func() IReactionType { return &ReactionTypeEmoji{} },
+	"custom_emoji": func() IReactionType { return &ReactionTypeCustomEmoji{} },
+	"paid":         func() IReactionType { return &ReactionTypePaid{} },
+}
+
+// UnmarshalReactionType decodes a ReactionType into its concrete type based
+// on the "type" field. Unknown types are decoded into ReactionTypeUnknown.
+func UnmarshalReactionType(data []byte) (IReactionType, error) {
+	return unmarshalWithKey(data, "type", reactionTypes, func(raw json.RawMessage) IReactionType {
+		return &ReactionTypeUnknown{Raw: raw}
+	})
+}
+
+func unmarshalReactionTypes(raw []json.RawMessage) ([]IReactionType, error) {
+	reactions := make([]IReactionType, 0, len(raw))
+	for _, r := range raw {
+		reaction, err := UnmarshalReactionType(r)
+		if err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+	return reactions, nil
 }
 
 type MessageReactionUpdated struct {
-	Chat        Chat
-	MessageId   int  `json:"message_id"`
-	User        User `json:"user,omitempty"`
-	ActorChat   Chat `json:"actor_chat,omitempty"`
-	Date        int
+	Chat      Chat `json:"chat"`
+	MessageId int  `json:"message_id"`
+	// Nil if the user is anonymous
+	User *User `json:"user,omitempty"`
+	// Nil unless the reaction was set by an anonymous chat administrator
+	ActorChat   *Chat           `json:"actor_chat,omitempty"`
+	Date        int             `json:"date"`
 	OldReaction []IReactionType `json:"old_reaction"`
 	NewReaction []IReactionType `json:"new_reaction"`
 }
 
+func (u *MessageReactionUpdated) UnmarshalJSON(data []byte) error {
+	type alias MessageReactionUpdated
+	aux := struct {
+		*alias
+		OldReaction []json.RawMessage `json:"old_reaction"`
+		NewReaction []json.RawMessage `json:"new_reaction"`
+	}{alias: (*alias)(u)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if u.OldReaction, err = unmarshalReactionTypes(aux.OldReaction); err != nil {
+		return err
+	}
+	u.NewReaction, err = unmarshalReactionTypes(aux.NewReaction)
+	return err
+}
+
 type ReactionCount struct {
-	Type       IReactionType
-	TotalCount int `json:"total_count"`
+	Type       IReactionType `json:"type"`
+	TotalCount int           `json:"total_count"`
+}
+
+func (c *ReactionCount) UnmarshalJSON(data []byte) error {
+	type alias ReactionCount
+	aux := struct {
+		*alias
+		Type json.RawMessage `json:"type"`
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	c.Type, err = UnmarshalReactionType(aux.Type)
+	return err
 }
 
 type MessageReactionCountUpdated struct {
-	Chat      Chat
-	MessageId int `json:"message_id"`
-	Date      int
+	Chat      Chat            `json:"chat"`
+	MessageId int             `json:"message_id"`
+	Date      int             `json:"date"`
 	Reactions []ReactionCount `json:"reactions"`
 }
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMessageReactionUpdated(t *testing.T) {
+	data := `{"chat":{"id":-1,"type":"group"},"message_id":1,"date":2,
+		"actor_chat":{"id":-1,"type":"group"},
+		"old_reaction":[{"type":"emoji","emoji":"👍"}],
+		"new_reaction":[{"type":"new_kind"},{"type":"paid"}]}`
+	updated := MessageReactionUpdated{}
+	if err := json.Unmarshal([]byte(data), &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.User != nil || updated.ActorChat == nil {
+		t.Errorf("User = %v, ActorChat = %v", updated.User, updated.ActorChat)
+	}
+	if len(updated.OldReaction) != 1 || len(updated.NewReaction) != 2 {
+		t.Fatalf("OldReaction = %v, NewReaction = %v", updated.OldReaction, updated.NewReaction)
+	}
+	if emoji, ok := updated.OldReaction[0].(*ReactionTypeEmoji); !ok || emoji.Emoji != "👍" {
+		t.Errorf("OldReaction[0] = %#v", updated.OldReaction[0])
+	}
+}
@@ -0,0 +1,105 @@
+package types
+
+type MessageAutoDeleteTimerChanged struct {
+	MessageAutoDeleteTime int `json:"message_auto_delete_time"`
+}
+
+type SharedUser struct {
+	UserId    int         `json:"user_id"`
+	FirstName string      `json:"first_name,omitempty"`
+	LastName  string      `json:"last_name,omitempty"`
+	Username  string      `json:"username,omitempty"`
+	Photo     []PhotoSize `json:"photo,omitempty"`
+}
+
+type UsersShared struct {
+	RequestId int          `json:"request_id"`
+	Users     []SharedUser `json:"users"`
+}
+
+type ChatShared struct {
+	RequestId int         `json:"request_id"`
+	ChatId    int         `json:"chat_id"`
+	Title     string      `json:"title,omitempty"`
+	Username  string      `json:"username,omitempty"`
+	Photo     []PhotoSize `json:"photo,omitempty"`
+}
+
+type WriteAccessAllowed struct {
+	FromRequest        bool   `json:"from_request,omitempty"`
+	WebAppName         string `json:"web_app_name,omitempty"`
+	FromAttachmentMenu bool   `json:"from_attachment_menu,omitempty"`
+}
+
+type ProximityAlertTriggered struct {
+	Traveler User `json:"traveler"`
+	Watcher  User `json:"watcher"`
+	Distance int  `json:"distance"`
+}
+
+type ChatBoostAdded struct {
+	BoostCount int `json:"boost_count"`
+}
+
+type ForumTopicCreated struct {
+	Name              string `json:"name"`
+	IconColor         int    `json:"icon_color"`
+	IconCustomEmojiId string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+type ForumTopicEdited struct {
+	Name              string  `json:"name,omitempty"`
+	IconCustomEmojiId *string `json:"icon_custom_emoji_id,omitempty"`
+}
+
+type ForumTopicClosed struct{}
+
+type ForumTopicReopened struct{}
+
+type GeneralForumTopicHidden struct{}
+
+type GeneralForumTopicUnhidden struct{}
+
+type VideoChatScheduled struct {
+	StartDate int64 `json:"start_date"`
+}
+
+type VideoChatStarted struct{}
+
+type VideoChatEnded struct {
+	Duration int `json:"duration"`
+}
+
+type VideoChatParticipantsInvited struct {
+	Users []User `json:"users"`
+}
+
+type SuggestedPostApproved struct {
+	SuggestedPostMessage *Message            `json:"suggested_post_message,omitempty"`
+	Price                *SuggestedPostPrice `json:"price,omitempty"`
+	SendDate             int64               `json:"send_date"`
+}
+
+type SuggestedPostApprovalFailed struct {
+	SuggestedPostMessage *Message           `json:"suggested_post_message,omitempty"`
+	Price                SuggestedPostPrice `json:"price"`
+}
+
+type SuggestedPostDeclined struct {
+	SuggestedPostMessage *Message `json:"suggested_post_message,omitempty"`
+	Comment              string   `json:"comment,omitempty"`
+}
+
+type SuggestedPostPaid struct {
+	SuggestedPostMessage *Message `json:"suggested_post_message,omitempty"`
+	Currency             string   `json:"currency"`
+	// Amount in nanotoncoins, only for "TON"
+	Amount     int         `json:"amount,omitempty"`
+	StarAmount *StarAmount `json:"star_amount,omitempty"`
+}
+
+type SuggestedPostRefunded struct {
+	SuggestedPostMessage *Message `json:"suggested_post_message,omitempty"`
+	// "post_deleted" or "payment_refunded"
+	Reason string `json:"reason"`
+}
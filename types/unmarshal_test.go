@@ -0,0 +1,100 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func typeName(v any) string {
+	return fmt.Sprintf("%T", v)
+}
+
+func unmarshalAny[T any](unmarshal func([]byte) (T, error)) func([]byte) (any, error) {
+	return func(data []byte) (any, error) {
+		return unmarshal(data)
+	}
+}
+
+func TestUnmarshalWithKey(t *testing.T) {
+	member := unmarshalAny(UnmarshalChatMember)
+	reaction := unmarshalAny(UnmarshalReactionType)
+	origin := unmarshalAny(UnmarshalMessageOrigin)
+	tests := []struct {
+		unmarshal func([]byte) (any, error)
+		key       string
+		data      string
+		want      string
+	}{
+		{member, "status", `{"status":"creator","user":{"id":1},"is_anonymous":false}`, "*types.ChatMemberOwner"},
+		{member, "status", `{"status":"administrator","user":{"id":1},"can_restrict_members":true}`, "*types.ChatMemberAdministrator"},
+		{member, "status", `{"status":"member","user":{"id":1}}`, "*types.ChatMemberMember"},
+		{member, "status", `{"status":"restricted","user":{"id":1},"is_member":true,"until_date":0}`, "*types.ChatMemberRestricted"},
+		{member, "status", `{"status":"left","user":{"id":1}}`, "*types.ChatMemberLeft"},
+		{member, "status", `{"status":"kicked","user":{"id":1},"until_date":0}`, "*types.ChatMemberBanned"},
+		{member, "status", `{"status":"new_status","user":{"id":1},"x":1}`, "*types.ChatMemberUnknown"},
+		{reaction, "type", `{"type":"emoji","emoji":"👍"}`, "*types.ReactionTypeEmoji"},
+		{reaction, "type", `{"type":"custom_emoji","custom_emoji_id":"123"}`, "*types.ReactionTypeCustomEmoji"},
+		{reaction, "type", `{"type":"paid"}`, "*types.ReactionTypePaid"},
+		{reaction, "type", `{"type":"new_kind","x":1}`, "*types.ReactionTypeUnknown"},
+		{origin, "type", `{"type":"user","date":1,"sender_user":{"id":5,"is_bot":false,"first_name":"a"}}`, "*types.MessageOriginUser"},
+		{origin, "type", `{"type":"hidden_user","date":1,"sender_user_name":"a"}`, "*types.MessageOriginHiddenUser"},
+		{origin, "type", `{"type":"chat","date":1,"sender_chat":{"id":-5,"type":"group"}}`, "*types.MessageOriginChat"},
+		{origin, "type", `{"type":"channel","date":1,"chat":{"id":-5,"type":"channel"},"message_id":3}`, "*types.MessageOriginChannel"},
+		{origin, "type", `{"type":"new_kind","date":1,"x":1}`, "*types.MessageOriginUnknown"},
+		{origin, "type", `{"date":1}`, "*types.MessageOriginUnknown"},
+	}
+	for _, tt := range tests {
+		v, err := tt.unmarshal([]byte(tt.data))
+		if err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if got := typeName(v); got != tt.want {
+			t.Errorf("%s: decoded into %s, want %s", tt.data, got, tt.want)
+		}
+
+		// the discriminator survives a round trip
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		in, out := map[string]any{}, map[string]any{}
+		json.Unmarshal([]byte(tt.data), &in)
+		json.Unmarshal(encoded, &out)
+		if in[tt.key] != out[tt.key] {
+			t.Errorf("%s: encoded as %s", tt.data, encoded)
+		}
+	}
+
+	for _, data := range []string{`[]`, `{"status":1}`, `{"status":"member","user":1}`} {
+		if _, err := UnmarshalChatMember([]byte(data)); err == nil {
+			t.Errorf("UnmarshalChatMember(%s) succeeded", data)
+		}
+	}
+}
+
+func TestUnknownKeepsRaw(t *testing.T) {
+	data := `{"type":"new_kind","x":1}`
+	reaction, err := UnmarshalReactionType([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown := reaction.(*ReactionTypeUnknown)
+	if unknown.Type != "new_kind" {
+		t.Errorf("Type = %q", unknown.Type)
+	}
+	encoded, _ := json.Marshal(unknown)
+	if string(encoded) != data {
+		t.Errorf("encoded as %s, want %s", encoded, data)
+	}
+
+	// Raw must not alias the decoded buffer
+	buf := []byte(data)
+	reaction, _ = UnmarshalReactionType(buf)
+	buf[2] = 'X'
+	if encoded, _ := json.Marshal(reaction); string(encoded) != data {
+		t.Errorf("Raw changed with the input buffer: %s", encoded)
+	}
+}
@@ -1,16 +1,48 @@
 package telbot
 
 import (
+	"context"
+	"errors"
+
 	"github.com/thehxdev/telbot/types"
 )
 
-// TODO: Complete the `Update` type
+type UpdateKind string
+
+// Update kinds, also used as values of `allowed_updates`
+const (
+	UpdateKindMessage                 UpdateKind = "message"
+	UpdateKindEditedMessage           UpdateKind = "edited_message"
+	UpdateKindChannelPost             UpdateKind = "channel_post"
+	UpdateKindEditedChannelPost       UpdateKind = "edited_channel_post"
+	UpdateKindBusinessConnection      UpdateKind = "business_connection"
+	UpdateKindBusinessMessage         UpdateKind = "business_message"
+	UpdateKindEditedBusinessMessage   UpdateKind = "edited_business_message"
+	UpdateKindDeletedBusinessMessages UpdateKind = "deleted_business_messages"
+	UpdateKindMessageReaction         UpdateKind = "message_reaction"
+	UpdateKindMessageReactionCount    UpdateKind = "message_reaction_count"
+	UpdateKindInlineQuery             UpdateKind = "inline_query"
+	UpdateKindChosenInlineResult      UpdateKind = "chosen_inline_result"
+	UpdateKindCallbackQuery           UpdateKind = "callback_query"
+	UpdateKindShippingQuery           UpdateKind = "shipping_query"
+	UpdateKindPreCheckoutQuery        UpdateKind = "pre_checkout_query"
+	UpdateKindPurchasedPaidMedia      UpdateKind = "purchased_paid_media"
+	UpdateKindPoll                    UpdateKind = "poll"
+	UpdateKindPollAnswer              UpdateKind = "poll_answer"
+	UpdateKindMyChatMember            UpdateKind = "my_chat_member"
+	UpdateKindChatMember              UpdateKind = "chat_member"
+	UpdateKindChatJoinRequest         UpdateKind = "chat_join_request"
+	UpdateKindChatBoost               UpdateKind = "chat_boost"
+	UpdateKindRemovedChatBoost        UpdateKind = "removed_chat_boost"
+	UpdateKindUnknown                 UpdateKind = ""
+)
+
 type Update struct {
 	Id                     int                                `json:"update_id"`
 	Message                *types.Message                     `json:"message,omitempty"`
 	EditedMessage          *types.Message                     `json:"edited_message,omitempty"`
 	ChannelPost            *types.Message                     `json:"channel_post,omitempty"`
-	EditedChannelPost      *types.Message                     `json:"edited_cahannel_post,omitempty"`
+	EditedChannelPost      *types.Message                     `json:"edited_channel_post,omitempty"`
 	BusinessConnection     *types.BusinessConnection          `json:"business_connection,omitempty"`
 	BusinessMessage        *types.Message                     `json:"business_message,omitempty"`
 	EditedBusinessMessage  *types.Message                     `json:"edited_business_message,omitempty"`
@@ -23,34 +55,213 @@ type Update struct {
 	ShippingQuery          *types.ShippingQuery               `json:"shipping_query,omitempty"`
 	PreCheckoutQuery       *types.PreCheckoutQuery            `json:"pre_checkout_query,omitempty"`
 	PurchasedPaidMedia     *types.PaidMediaPurchased          `json:"purchased_paid_media,omitempty"`
+	Poll                   *types.Poll                        `json:"poll,omitempty"`
+	PollAnswer             *types.PollAnswer                  `json:"poll_answer,omitempty"`
+	MyChatMember           *types.ChatMemberUpdated           `json:"my_chat_member,omitempty"`
+	ChatMember             *types.ChatMemberUpdated           `json:"chat_member,omitempty"`
+	ChatJoinRequest        *types.ChatJoinRequest             `json:"chat_join_request,omitempty"`
+	ChatBoost              *types.ChatBoostUpdated            `json:"chat_boost,omitempty"`
+	RemovedChatBoost       *types.ChatBoostRemoved            `json:"removed_chat_boost,omitempty"`
 
 	Bot *Bot `json:"-"`
 }
 
+// Kind returns the kind of the update. Each update has exactly one
+// of its optional fields set.
+func (u *Update) Kind() UpdateKind {
+	switch {
+	case u.Message != nil:
+		return UpdateKindMessage
+	case u.EditedMessage != nil:
+		return UpdateKindEditedMessage
+	case u.ChannelPost != nil:
+		return UpdateKindChannelPost
+	case u.EditedChannelPost != nil:
+		return UpdateKindEditedChannelPost
+	case u.BusinessConnection != nil:
+		return UpdateKindBusinessConnection
+	case u.BusinessMessage != nil:
+		return UpdateKindBusinessMessage
+	case u.EditedBusinessMessage != nil:
+		return UpdateKindEditedBusinessMessage
+	case u.DeletedBusinessMessage != nil:
+		return UpdateKindDeletedBusinessMessages
+	case u.MessageReaction != nil:
+		return UpdateKindMessageReaction
+	case u.MessageReactionCount != nil:
+		return UpdateKindMessageReactionCount
+	case u.InlineQuery != nil:
+		return UpdateKindInlineQuery
+	case u.ChosenInlineResult != nil:
+		return UpdateKindChosenInlineResult
+	case u.CallbackQuery != nil:
+		return UpdateKindCallbackQuery
+	case u.ShippingQuery != nil:
+		return UpdateKindShippingQuery
+	case u.PreCheckoutQuery != nil:
+		return UpdateKindPreCheckoutQuery
+	case u.PurchasedPaidMedia != nil:
+		return UpdateKindPurchasedPaidMedia
+	case u.Poll != nil:
+		return UpdateKindPoll
+	case u.PollAnswer != nil:
+		return UpdateKindPollAnswer
+	case u.MyChatMember != nil:
+		return UpdateKindMyChatMember
+	case u.ChatMember != nil:
+		return UpdateKindChatMember
+	case u.ChatJoinRequest != nil:
+		return UpdateKindChatJoinRequest
+	case u.ChatBoost != nil:
+		return UpdateKindChatBoost
+	case u.RemovedChatBoost != nil:
+		return UpdateKindRemovedChatBoost
+	}
+	return UpdateKindUnknown
+}
+
+// EffectiveMessage returns the message carried by the update, or nil if
+// the update has no message.
+func (u *Update) EffectiveMessage() *types.Message {
+	switch {
+	case u.Message != nil:
+		return u.Message
+	case u.EditedMessage != nil:
+		return u.EditedMessage
+	case u.ChannelPost != nil:
+		return u.ChannelPost
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost
+	case u.BusinessMessage != nil:
+		return u.BusinessMessage
+	case u.EditedBusinessMessage != nil:
+		return u.EditedBusinessMessage
+	case u.CallbackQuery != nil && u.CallbackQuery.Message != nil:
+		return u.CallbackQuery.Message.Message
+	}
+	return nil
+}
+
+// EffectiveChat returns the chat the update belongs to, or nil if there
+// is no such chat (e.g. inline queries).
+func (u *Update) EffectiveChat() *types.Chat {
+	if msg := u.EffectiveMessage(); msg != nil {
+		return msg.Chat
+	}
+	switch {
+	case u.CallbackQuery != nil && u.CallbackQuery.Message != nil:
+		return u.CallbackQuery.Message.GetChat()
+	case u.MessageReaction != nil:
+		return &u.MessageReaction.Chat
+	case u.MessageReactionCount != nil:
+		return &u.MessageReactionCount.Chat
+	case u.DeletedBusinessMessage != nil:
+		return &u.DeletedBusinessMessage.Chat
+	case u.MyChatMember != nil:
+		return &u.MyChatMember.Chat
+	case u.ChatMember != nil:
+		return &u.ChatMember.Chat
+	case u.ChatJoinRequest != nil:
+		return &u.ChatJoinRequest.Chat
+	case u.ChatBoost != nil:
+		return &u.ChatBoost.Chat
+	case u.RemovedChatBoost != nil:
+		return &u.RemovedChatBoost.Chat
+	}
+	return nil
+}
+
+// EffectiveUser returns the user that caused the update, or nil if it's
+// unknown (e.g. channel posts and anonymous reactions).
+func (u *Update) EffectiveUser() *types.User {
+	if u.CallbackQuery != nil {
+		// the message of a callback query is sent by the bot
+		return &u.CallbackQuery.From
+	}
+	if msg := u.EffectiveMessage(); msg != nil {
+		return msg.From
+	}
+	switch {
+	case u.InlineQuery != nil:
+		return &u.InlineQuery.From
+	case u.ChosenInlineResult != nil:
+		return &u.ChosenInlineResult.From
+	case u.ShippingQuery != nil:
+		return &u.ShippingQuery.From
+	case u.PreCheckoutQuery != nil:
+		return &u.PreCheckoutQuery.From
+	case u.PurchasedPaidMedia != nil:
+		return &u.PurchasedPaidMedia.From
+	case u.PollAnswer != nil:
+		return u.PollAnswer.User
+	case u.MessageReaction != nil:
+		return u.MessageReaction.User
+	case u.BusinessConnection != nil:
+		return &u.BusinessConnection.User
+	case u.MyChatMember != nil:
+		return &u.MyChatMember.From
+	case u.ChatMember != nil:
+		return &u.ChatMember.From
+	case u.ChatJoinRequest != nil:
+		return &u.ChatJoinRequest.From
+	}
+	return nil
+}
+
 func (u *Update) ChatId() int {
-	if u.Message.Chat != nil {
-		return u.Message.Chat.Id
+	if chat := u.EffectiveChat(); chat != nil {
+		return chat.Id
 	}
 	return defaultInvalidId
 }
 
 func (u *Update) UserId() int {
-	if u.Message.From != nil {
-		return u.Message.From.Id
+	if user := u.EffectiveUser(); user != nil {
+		return user.Id
 	}
 	return defaultInvalidId
 }
 
 func (u *Update) MessageId() int {
-	if u.Message != nil {
-		return u.Message.Id
+	if msg := u.EffectiveMessage(); msg != nil {
+		return msg.Id
 	}
 	return defaultInvalidId
 }
 
 func (u *Update) ChatType() string {
-	if u.Message.Chat != nil {
-		return u.Message.Chat.Type
+	if chat := u.EffectiveChat(); chat != nil {
+		return chat.Type
 	}
 	return ""
 }
+
+// AnswerCallback answers the callback query of the update with a
+// notification at the top of the chat screen. text may be empty. The
+// answer helpers are defined on Update rather than on
+// types.CallbackQuery because the types package can't import telbot to
+// send requests.
+func (u *Update) AnswerCallback(ctx context.Context, text string) error {
+	return u.answerCallback(ctx, AnswerCallbackQueryParams{Text: text})
+}
+
+// AnswerCallbackAlert answers the callback query of the update with an
+// alert that the user has to dismiss.
+func (u *Update) AnswerCallbackAlert(ctx context.Context, text string) error {
+	return u.answerCallback(ctx, AnswerCallbackQueryParams{Text: text, ShowAlert: true})
+}
+
+// AnswerCallbackUrl answers the callback query of the update by opening
+// url. Only game and t.me/your_bot?start= URLs are allowed.
+func (u *Update) AnswerCallbackUrl(ctx context.Context, url string) error {
+	return u.answerCallback(ctx, AnswerCallbackQueryParams{Url: url})
+}
+
+func (u *Update) answerCallback(ctx context.Context, params AnswerCallbackQueryParams) error {
+	if u.CallbackQuery == nil {
+		return errors.New("update has no callback query")
+	}
+	params.CallbackQueryId = u.CallbackQuery.Id
+	_, err := u.Bot.AnswerCallbackQuery(ctx, params)
+	return err
+}
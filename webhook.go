@@ -0,0 +1,123 @@
+package telbot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/thehxdev/telbot/types"
+)
+
+const (
+	secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+	// upper limit for the size of an update sent to the webhook
+	maxWebhookBodySize = 1 << 20
+)
+
+type WebhookParams struct {
+	Url                string       `json:"url"`
+	IpAddress          string       `json:"ip_address,omitempty"`
+	MaxConnections     int          `json:"max_connections,omitempty"`
+	AllowedUpdates     []UpdateKind `json:"allowed_updates,omitempty"`
+	DropPendingUpdates bool         `json:"drop_pending_updates,omitempty"`
+	SecretToken        string       `json:"secret_token,omitempty"`
+}
+
+func (b *Bot) SetWebhook(ctx context.Context, params WebhookParams) (bool, error) {
+	body, _ := ParamsToReader(params)
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodSetWebhook,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+func (b *Bot) DeleteWebhook(ctx context.Context, dropPendingUpdates bool) (bool, error) {
+	body, _ := ParamsToReader(map[string]bool{"drop_pending_updates": dropPendingUpdates})
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:      MethodDeleteWebhook,
+		Body:        body,
+		ContentType: ContentTypeApplicationJson,
+		Timeout:     defaultOperationTimeout,
+	})
+	return apiResp.Ok, err
+}
+
+func (b *Bot) GetWebhookInfo(ctx context.Context) (*types.WebhookInfo, error) {
+	apiResp, err := b.SendRequest(ctx, b.BaseUrl, RequestInfo{
+		Method:  MethodGetWebhookInfo,
+		Timeout: defaultOperationTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info := &types.WebhookInfo{}
+	err = json.Unmarshal(apiResp.Result, info)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// WebhookHandler returns an http.Handler that receives updates sent by
+// telegram and the channel those updates are delivered to. If
+// secretToken is not empty, requests without a matching
+// "X-Telegram-Bot-Api-Secret-Token" header are rejected. Register the
+// handler on your own server and call SetWebhook with the same secret.
+func (b *Bot) WebhookHandler(secretToken string, bufferSize int) (http.Handler, <-chan Update) {
+	updatesChan := make(chan Update, bufferSize)
+	return &webhookHandler{
+		bot:         b,
+		secretToken: secretToken,
+		updatesChan: updatesChan,
+	}, updatesChan
+}
+
+type webhookHandler struct {
+	bot         *Bot
+	secretToken string
+	updatesChan chan Update
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.secretToken != "" {
+		got := r.Header.Get(secretTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(h.secretToken)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	update := Update{}
+	body := http.MaxBytesReader(w, r.Body, maxWebhookBodySize)
+	if err := json.NewDecoder(body).Decode(&update); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	update.Bot = h.bot
+
+	select {
+	case h.updatesChan <- update:
+		w.WriteHeader(http.StatusOK)
+	case <-r.Context().Done():
+		// telegram repeats the update if we don't respond with 2xx
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	}
+}
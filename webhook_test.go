@@ -0,0 +1,69 @@
+package telbot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	const update = `{"update_id": 7, "message": {"message_id": 1, "date": 0, "chat": {"id": 5, "type": "private"}, "text": "hi"}}`
+	tests := []struct {
+		name   string
+		method string
+		secret string
+		body   string
+		status int
+	}{
+		{"delivered", http.MethodPost, "secret", update, http.StatusOK},
+		{"missing secret", http.MethodPost, "", update, http.StatusUnauthorized},
+		{"wrong secret", http.MethodPost, "wrong", update, http.StatusUnauthorized},
+		{"not a POST", http.MethodGet, "secret", "", http.StatusMethodNotAllowed},
+		{"bad body", http.MethodPost, "secret", "{", http.StatusBadRequest},
+		{"body too large", http.MethodPost, "secret", `{"x": "` + strings.Repeat("a", maxWebhookBodySize) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot := &Bot{}
+			handler, updates := bot.WebhookHandler("secret", 1)
+
+			req := httptest.NewRequest(tt.method, "/webhook", strings.NewReader(tt.body))
+			if tt.secret != "" {
+				req.Header.Set(secretTokenHeader, tt.secret)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				if len(updates) != 0 {
+					t.Error("rejected request delivered an update")
+				}
+				return
+			}
+			select {
+			case u := <-updates:
+				if u.Id != 7 || u.Message == nil || u.Message.Text != "hi" {
+					t.Errorf("unexpected update %+v", u)
+				}
+				if u.Bot != bot {
+					t.Error("update.Bot is not set")
+				}
+			default:
+				t.Fatal("no update was delivered")
+			}
+		})
+	}
+}
+
+func TestWebhookHandlerAllowHeader(t *testing.T) {
+	handler, _ := (&Bot{}).WebhookHandler("", 1)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/", nil))
+	if got := rec.Header().Get("Allow"); got != http.MethodPost {
+		t.Errorf("Allow = %q, want POST", got)
+	}
+}